@@ -0,0 +1,154 @@
+package jpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// 单次请求解绑的注册 ID 的最大数量
+const aliasBatchSize = 1000
+
+// NewAliasClient 创建别名客户端实例
+func NewAliasClient(opts ...Option) *AliasClient {
+	return newAliasClient(newOptions(opts...))
+}
+
+func newAliasClient(opts *options) *AliasClient {
+	return &AliasClient{
+		opts: opts,
+	}
+}
+
+// AliasClient 别名客户端
+type AliasClient struct {
+	opts *options
+}
+
+// Get 查询别名下的设备(platforms 为空时查询全部平台)
+func (c *AliasClient) Get(ctx context.Context, alias string, platforms ...OS) (*AliasInfo, error) {
+	router := fmt.Sprintf("/v3/aliases/%s", url.PathEscape(alias))
+	if len(platforms) > 0 {
+		values := make([]string, len(platforms))
+		for i, platform := range platforms {
+			values[i] = platform.String()
+		}
+
+		params := make(url.Values)
+		params.Set("platform", strings.Join(values, ","))
+		router = fmt.Sprintf("%s?%s", router, params.Encode())
+	}
+
+	result := &AliasInfo{Alias: alias}
+	err := retryRequest(ctx, c.opts, routerEndpoint(router), func() error {
+		resp, err := deviceRequest(ctx, c.opts, router, http.MethodGet, nil)
+		if err != nil {
+			return err
+		}
+		result.HeaderItem = newHeaderItem(resp.Response().Header)
+		return resp.JSON(result)
+	})
+	if err != nil {
+		return nil, newAliasError(alias, err)
+	}
+	return result, nil
+}
+
+// Delete 删除别名及其与设备的绑定
+func (c *AliasClient) Delete(ctx context.Context, alias string) error {
+	router := fmt.Sprintf("/v3/aliases/%s", url.PathEscape(alias))
+	err := retryRequest(ctx, c.opts, routerEndpoint(router), func() error {
+		resp, err := deviceRequest(ctx, c.opts, router, http.MethodDelete, nil)
+		if err != nil {
+			return err
+		}
+		resp.Close()
+		return nil
+	})
+	return newAliasError(alias, err)
+}
+
+// Remove 解绑别名下的设备，按接口限制自动分批，部分批次失败时返回 *BulkError
+func (c *AliasClient) Remove(ctx context.Context, alias string, registrationIDs ...string) ([]*BatchResult, error) {
+	router := fmt.Sprintf("/v3/aliases/%s", url.PathEscape(alias))
+
+	var results []*BatchResult
+	for _, items := range chunkStrings(registrationIDs, aliasBatchSize) {
+		result := &BatchResult{Items: items}
+		results = append(results, result)
+
+		if err := ctx.Err(); err != nil {
+			result.Err = err
+			continue
+		}
+
+		body, err := json.Marshal(map[string]interface{}{
+			"registration_ids": map[string][]string{"remove": items},
+		})
+		if err != nil {
+			result.Err = err
+			continue
+		}
+
+		err = retryRequest(ctx, c.opts, routerEndpoint(router), func() error {
+			resp, err := deviceRequest(ctx, c.opts, router, http.MethodPost, bytes.NewReader(body))
+			if err != nil {
+				return err
+			}
+			resp.Close()
+			return nil
+		})
+		result.Err = newAliasError(alias, err)
+	}
+
+	return results, newBulkError(results)
+}
+
+// AliasInfo 别名下的设备
+type AliasInfo struct {
+	Alias           string      `json:"-"`
+	RegistrationIDs []string    `json:"registration_ids"`
+	HeaderItem      *HeaderItem `json:"-"`
+}
+
+// newAliasError 将接口错误包装为 *AliasError，其他错误原样返回
+func newAliasError(alias string, err error) error {
+	var e *Error
+	if errors.As(err, &e) {
+		return &AliasError{Alias: alias, Err: e}
+	}
+	return err
+}
+
+// AliasError 别名接口错误
+type AliasError struct {
+	Alias string
+	Err   *Error
+}
+
+func (e *AliasError) Error() string {
+	return fmt.Sprintf("alias %s: %s", e.Alias, e.Err.Error())
+}
+
+// Unwrap 返回接口错误
+func (e *AliasError) Unwrap() error {
+	return e.Err
+}
+
+// NotFound 别名不存在
+func (e *AliasError) NotFound() bool {
+	return e.Err.StatusCode == http.StatusNotFound
+}
+
+// Code 接口错误码
+func (e *AliasError) Code() int {
+	if e.Err.ErrorItem == nil {
+		return 0
+	}
+	return e.Err.ErrorItem.Code
+}
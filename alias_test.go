@@ -0,0 +1,82 @@
+package jpush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAliasClient(t *testing.T) {
+	Convey("test alias client", t, func() {
+		var (
+			path     string
+			platform string
+			body     map[string]map[string][]string
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.Method + " " + r.URL.Path
+			platform = r.URL.Query().Get("platform")
+
+			if r.URL.Path == "/v3/aliases/missing" {
+				w.WriteHeader(http.StatusNotFound)
+				w.Write([]byte(`{"error":{"code":7013,"message":"alias not found"}}`))
+				return
+			}
+
+			switch r.Method {
+			case http.MethodGet:
+				w.Header().Set("X-Rate-Limit-Quota", "600")
+				w.Header().Set("X-Rate-Limit-Remaining", "599")
+				w.Header().Set("X-Rate-Limit-Reset", "60")
+				w.Write([]byte(`{"registration_ids":["rid-1","rid-2"]}`))
+			case http.MethodPost:
+				json.NewDecoder(r.Body).Decode(&body)
+			}
+		}))
+		defer srv.Close()
+
+		cli := NewAliasClient(SetDeviceHost(srv.URL), SetAppKey(appKey), SetMasterSecret(masterSecret))
+		ctx := context.Background()
+
+		info, err := cli.Get(ctx, "lyric", Android)
+		So(err, ShouldBeNil)
+		So(path, ShouldEqual, "GET /v3/aliases/lyric")
+		So(platform, ShouldEqual, "android")
+		So(info.Alias, ShouldEqual, "lyric")
+		So(info.RegistrationIDs, ShouldResemble, []string{"rid-1", "rid-2"})
+		So(info.HeaderItem.XRateLimitRemaining, ShouldEqual, 599)
+
+		_, err = cli.Remove(ctx, "lyric", "rid-1")
+		So(err, ShouldBeNil)
+		So(path, ShouldEqual, "POST /v3/aliases/lyric")
+		So(body, ShouldResemble, map[string]map[string][]string{
+			"registration_ids": {"remove": {"rid-1"}},
+		})
+
+		So(cli.Delete(ctx, "lyric"), ShouldBeNil)
+		So(path, ShouldEqual, "DELETE /v3/aliases/lyric")
+
+		_, err = cli.Get(ctx, "missing")
+		var aliasErr *AliasError
+		So(errors.As(err, &aliasErr), ShouldBeTrue)
+		So(aliasErr.Alias, ShouldEqual, "missing")
+		So(aliasErr.NotFound(), ShouldBeTrue)
+		So(aliasErr.Code(), ShouldEqual, 7013)
+
+		var apiErr *Error
+		So(errors.As(err, &apiErr), ShouldBeTrue)
+		So(apiErr.StatusCode, ShouldEqual, http.StatusNotFound)
+
+		results, err := cli.Remove(ctx, "missing", "rid-1")
+		var bulk *BulkError
+		So(errors.As(err, &bulk), ShouldBeTrue)
+		So(bulk.Failed, ShouldHaveLength, 1)
+		So(errors.As(results[0].Err, &aliasErr), ShouldBeTrue)
+		So(aliasErr.NotFound(), ShouldBeTrue)
+	})
+}
@@ -0,0 +1,49 @@
+package jpush
+
+import (
+	"fmt"
+)
+
+// chunkStrings 将 items 按 size 切分为多个批次
+func chunkStrings(items []string, size int) [][]string {
+	var chunks [][]string
+	for len(items) > size {
+		chunks = append(chunks, items[:size])
+		items = items[size:]
+	}
+	if len(items) > 0 {
+		chunks = append(chunks, items)
+	}
+	return chunks
+}
+
+// BatchResult 分批请求中单个批次的结果
+type BatchResult struct {
+	Items []string // 本批次的注册 ID 等请求项
+	Err   error    // 本批次的错误
+}
+
+// newBulkError 汇总分批请求中失败的批次，全部成功时返回 nil
+func newBulkError(results []*BatchResult) error {
+	e := &BulkError{Total: len(results)}
+	for _, result := range results {
+		if result.Err != nil {
+			e.Failed = append(e.Failed, result)
+		}
+	}
+
+	if len(e.Failed) == 0 {
+		return nil
+	}
+	return e
+}
+
+// BulkError 分批请求部分或全部失败
+type BulkError struct {
+	Total  int            // 批次总数
+	Failed []*BatchResult // 失败的批次
+}
+
+func (e *BulkError) Error() string {
+	return fmt.Sprintf("%d of %d batches failed: %v", len(e.Failed), e.Total, e.Failed[0].Err)
+}
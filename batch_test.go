@@ -0,0 +1,31 @@
+package jpush
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestChunkStrings(t *testing.T) {
+	Convey("test chunk strings", t, func() {
+		items := make([]string, 2500)
+		for i := range items {
+			items[i] = strconv.Itoa(i)
+		}
+
+		chunks := chunkStrings(items, tagBatchSize)
+		So(chunks, ShouldHaveLength, 3)
+		So(chunks[0], ShouldHaveLength, 1000)
+		So(chunks[2], ShouldHaveLength, 500)
+		So(chunks[2][499], ShouldEqual, "2499")
+		So(chunkStrings(nil, tagBatchSize), ShouldBeEmpty)
+
+		results := []*BatchResult{{Items: chunks[0]}, {Items: chunks[1], Err: errors.New("failed")}}
+		err := newBulkError(results)
+		So(err, ShouldNotBeNil)
+		So(err.(*BulkError).Failed, ShouldHaveLength, 1)
+		So(newBulkError(results[:1]), ShouldBeNil)
+	})
+}
@@ -0,0 +1,246 @@
+package jpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 批量单推单次请求的最大推送数量
+const batchPushSize = 500
+
+// BatchTarget 批量单推的推送目标类型
+type BatchTarget string
+
+// 定义批量单推的推送目标类型
+const (
+	BatchRegistrationID BatchTarget = "regid"
+	BatchAlias          BatchTarget = "alias"
+)
+
+// BatchPushResult 批量单推中单个推送目标的结果
+type BatchPushResult struct {
+	Target string // 推送目标(注册 ID 或别名)
+	CID    string // 推送唯一标识符
+	MsgID  string // 消息 ID
+	Err    error  // 推送错误
+}
+
+type batchPushItem struct {
+	Platform     *Platform     `json:"platform"`
+	Target       string        `json:"target"`
+	Notification *Notification `json:"notification,omitempty"`
+	Message      *Message      `json:"message,omitempty"`
+	SmsMessage   *SmsMessage   `json:"sms_message,omitempty"`
+	Options      *Options      `json:"options,omitempty"`
+}
+
+// BatchSinglePush 批量单推，payloads 为推送目标(注册 ID 或别名)到推送载荷的映射(忽略载荷中的 Audience)，
+// 按接口限制分批后交由推送队列执行，返回每个推送目标的结果
+func (c *Client) BatchSinglePush(ctx context.Context, target BatchTarget, payloads map[string]*Payload) (map[string]*BatchPushResult, error) {
+	c.lock.Lock()
+	closed := c.closed
+	c.lock.Unlock()
+	if closed {
+		return nil, ErrClientClosed
+	}
+
+	targets := make([]string, 0, len(payloads))
+	for t := range payloads {
+		targets = append(targets, t)
+	}
+	sort.Strings(targets)
+
+	for _, t := range targets {
+		if err := payloads[t].Validate(); err != nil {
+			return nil, fmt.Errorf("%s: %w", t, err)
+		}
+	}
+
+	results := make(map[string]*BatchPushResult, len(targets))
+	for _, t := range targets {
+		results[t] = &BatchPushResult{Target: t, CID: payloads[t].CID}
+	}
+
+	// 持有 termLock 直至放入队列，避免与 Terminate 交错
+	c.termLock.RLock()
+	c.lock.Lock()
+	if c.closed {
+		c.lock.Unlock()
+		c.termLock.RUnlock()
+		return nil, ErrClientClosed
+	}
+
+	var wg sync.WaitGroup
+	chunks := chunkStrings(targets, batchPushSize)
+	jobs := make([]*batchPushJob, 0, len(chunks))
+	for _, items := range chunks {
+		job := &batchPushJob{
+			queuedJob: queuedJob{opts: c.opts, enqueue: c.enqueue},
+			cidClient: c.cidClient,
+			ctx:       ctx,
+			target:    target,
+			payloads:  payloads,
+			results:   results,
+			targets:   items,
+		}
+		job.done = func() {
+			c.release(job)
+			wg.Done()
+		}
+		c.pending[job] = struct{}{}
+		jobs = append(jobs, job)
+	}
+	wg.Add(len(jobs))
+	c.lock.Unlock()
+
+	for _, job := range jobs {
+		c.queue.Push(job)
+	}
+	c.termLock.RUnlock()
+
+	ch := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(ch)
+	}()
+
+	select {
+	case <-ch:
+		return results, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// batchPushJob 批量单推中的一个批次，各批次写入 results 中互不重叠的推送目标
+type batchPushJob struct {
+	queuedJob
+	cidClient *CIDClient
+	ctx       context.Context
+	target    BatchTarget
+	payloads  map[string]*Payload
+	results   map[string]*BatchPushResult
+	targets   []string
+	attempts  int
+	done      func()
+}
+
+// router 批量单推接口
+func (j *batchPushJob) router() string {
+	return fmt.Sprintf("/v3/push/batch/%s/single", j.target)
+}
+
+// pendingPayloads 批次中每个推送目标的载荷(Audience 为该推送目标)
+func (j *batchPushJob) pendingPayloads() []*Payload {
+	payloads := make([]*Payload, 0, len(j.targets))
+	for _, t := range j.targets {
+		payload := *j.payloads[t]
+		if j.target == BatchAlias {
+			payload.Audience = NewAudience().SetAlias(t)
+		} else {
+			payload.Audience = NewAudience().SetRegistrationID(t)
+		}
+		payloads = append(payloads, &payload)
+	}
+	return payloads
+}
+
+func (j *batchPushJob) requeue(delay time.Duration) {
+	j.schedule(j, delay, func() {
+		j.fail(ErrClientClosed)
+	})
+}
+
+func (j *batchPushJob) fail(err error) {
+	for _, t := range j.targets {
+		j.results[t].Err = err
+	}
+	j.done()
+}
+
+func (j *batchPushJob) Job() {
+	if d := j.reserve(j.router()); d > 0 {
+		j.requeue(d)
+		return
+	}
+	j.attempts++
+
+	err := j.push()
+	if err == nil {
+		j.done()
+		return
+	}
+
+	policy := j.opts.retryPolicy
+	if j.ctx.Err() != nil || policy == nil || !policy.Retryable(err) || policy.Exhausted(j.attempts) {
+		j.fail(err)
+		return
+	}
+	j.requeue(policy.Backoff(j.attempts))
+}
+
+func (j *batchPushJob) push() error {
+	pushList := make(map[string]*batchPushItem, len(j.targets))
+	cids := make(map[string]string, len(j.targets))
+	for _, t := range j.targets {
+		result := j.results[t]
+		if result.CID == "" {
+			cid, err := j.cidClient.GetPushID(j.ctx)
+			if err != nil {
+				return err
+			}
+			result.CID = cid
+		}
+
+		payload := j.payloads[t]
+		pushList[result.CID] = &batchPushItem{
+			Platform:     payload.Platform,
+			Target:       t,
+			Notification: payload.Notification,
+			Message:      payload.Message,
+			SmsMessage:   payload.SmsMessage,
+			Options:      payload.Options,
+		}
+		cids[result.CID] = t
+	}
+
+	buf, err := json.Marshal(map[string]interface{}{"pushlist": pushList})
+	if err != nil {
+		return err
+	}
+
+	resp, err := pushRequest(j.ctx, j.opts, j.router(), http.MethodPost, bytes.NewReader(buf))
+	if err != nil {
+		return err
+	}
+
+	var items map[string]struct {
+		MsgID string     `json:"msg_id"`
+		Error *ErrorItem `json:"error"`
+	}
+	err = resp.JSON(&items)
+	if err != nil {
+		return err
+	}
+
+	for cid, t := range cids {
+		result := j.results[t]
+		item, ok := items[cid]
+		if !ok {
+			result.Err = &Error{StatusCode: http.StatusOK, ErrorItem: NewErrorItem(0, "missing result")}
+			continue
+		}
+
+		result.MsgID = item.MsgID
+		if item.Error != nil {
+			result.Err = &Error{StatusCode: http.StatusOK, ErrorItem: item.Error}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,169 @@
+package jpush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// batchPushServer 模拟推送唯一标识符及批量单推接口，block 不为空时批量单推请求等待其关闭
+func batchPushServer(block chan struct{}) (*httptest.Server, func() ([]int, map[string]string)) {
+	var (
+		lock  sync.Mutex
+		next  int
+		sizes []int
+		cids  = make(map[string]string)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3/push/cid" {
+			count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+			lock.Lock()
+			list := make([]string, count)
+			for i := range list {
+				next++
+				list[i] = fmt.Sprintf("cid-%d", next)
+			}
+			lock.Unlock()
+			json.NewEncoder(w).Encode(map[string]interface{}{"cidlist": list})
+			return
+		}
+
+		if block != nil {
+			<-block
+		}
+
+		var req struct {
+			PushList map[string]*batchPushItem `json:"pushlist"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		lock.Lock()
+		sizes = append(sizes, len(req.PushList))
+		resp := make(map[string]interface{}, len(req.PushList))
+		for cid, item := range req.PushList {
+			cids[item.Target] = cid
+			switch item.Target {
+			case "t-000":
+				resp[cid] = map[string]interface{}{"error": map[string]interface{}{"code": 1011, "message": "cannot find user by this audience"}}
+			case "t-001":
+				// 缺少推送结果
+			default:
+				resp[cid] = map[string]interface{}{"msg_id": "m-" + item.Target}
+			}
+		}
+		lock.Unlock()
+		json.NewEncoder(w).Encode(resp)
+	}))
+
+	return srv, func() ([]int, map[string]string) {
+		lock.Lock()
+		defer lock.Unlock()
+		return sizes, cids
+	}
+}
+
+func TestBatchSinglePush(t *testing.T) {
+	Convey("test batch single push", t, func() {
+		srv, recorded := batchPushServer(nil)
+		defer srv.Close()
+
+		cli := NewClient(2, SetHost(srv.URL), SetAppKey(appKey), SetMasterSecret(masterSecret))
+		defer cli.Terminate()
+
+		payloads := make(map[string]*Payload)
+		for i := 0; i <= batchPushSize; i++ {
+			payloads[fmt.Sprintf("t-%03d", i)] = &Payload{
+				Platform:     NewPlatform().All(),
+				Notification: &Notification{Alert: "hello"},
+			}
+		}
+		payloads["t-002"].CID = "preset"
+
+		results, err := cli.BatchSinglePush(context.Background(), BatchRegistrationID, payloads)
+		So(err, ShouldBeNil)
+		So(results, ShouldHaveLength, batchPushSize+1)
+
+		sizes, cids := recorded()
+		So(sizes, ShouldHaveLength, 2)
+		So(sizes[0]+sizes[1], ShouldEqual, batchPushSize+1)
+		So(sizes[0] == batchPushSize || sizes[1] == batchPushSize, ShouldBeTrue)
+
+		for target, result := range results {
+			So(result.Target, ShouldEqual, target)
+			So(result.CID, ShouldEqual, cids[target])
+		}
+		So(results["t-002"].CID, ShouldEqual, "preset")
+		So(results["t-500"].MsgID, ShouldEqual, "m-t-500")
+		So(results["t-500"].Err, ShouldBeNil)
+
+		err = results["t-000"].Err
+		So(err, ShouldHaveSameTypeAs, &Error{})
+		So(err.(*Error).ErrorItem.Code, ShouldEqual, 1011)
+
+		err = results["t-001"].Err
+		So(err, ShouldHaveSameTypeAs, &Error{})
+		So(err.(*Error).ErrorItem.Message, ShouldEqual, "missing result")
+	})
+
+	Convey("test shutdown waits for batch single push", t, func() {
+		block := make(chan struct{})
+		srv, _ := batchPushServer(block)
+		defer srv.Close()
+
+		cli := NewClient(2, SetHost(srv.URL), SetAppKey(appKey), SetMasterSecret(masterSecret))
+		payloads := map[string]*Payload{
+			"a-1": {Platform: NewPlatform().All(), Notification: &Notification{Alert: "hello"}},
+		}
+
+		type batchResult struct {
+			results map[string]*BatchPushResult
+			err     error
+		}
+		done := make(chan batchResult, 1)
+		go func() {
+			results, err := cli.BatchSinglePush(context.Background(), BatchAlias, payloads)
+			done <- batchResult{results, err}
+		}()
+
+		// 等待批次放入待完成集合
+		for {
+			cli.lock.Lock()
+			n := len(cli.pending)
+			cli.lock.Unlock()
+			if n > 0 {
+				break
+			}
+			time.Sleep(time.Millisecond)
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+
+		err := cli.Shutdown(ctx)
+		So(err, ShouldHaveSameTypeAs, &ShutdownError{})
+		pending := err.(*ShutdownError).Pending
+		So(pending, ShouldHaveLength, 1)
+		So(pending[0].Audience.Value["alias"], ShouldResemble, []string{"a-1"})
+		So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+
+		// 关闭超时后，正在执行的批次完成时移出待完成集合
+		close(block)
+		So(cli.Shutdown(context.Background()), ShouldBeNil)
+
+		result := <-done
+		So(result.err, ShouldBeNil)
+		So(result.results["a-1"].MsgID, ShouldEqual, "m-a-1")
+	})
+}
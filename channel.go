@@ -0,0 +1,257 @@
+package jpush
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrInvalidOptions 无效的可选参数
+	ErrInvalidOptions = errors.New("invalid options")
+)
+
+// ChannelVendor 厂商通道
+type ChannelVendor string
+
+func (v ChannelVendor) String() string {
+	return string(v)
+}
+
+// 定义厂商通道
+const (
+	VendorXiaomi ChannelVendor = "xiaomi"
+	VendorHuawei ChannelVendor = "huawei"
+	VendorHonor  ChannelVendor = "honor"
+	VendorOPPO   ChannelVendor = "oppo"
+	VendorVivo   ChannelVendor = "vivo"
+	VendorMeizu  ChannelVendor = "meizu"
+	VendorFCM    ChannelVendor = "fcm"
+)
+
+// 定义通知栏消息下发逻辑(distribution)
+const (
+	DistributionOSPush        = "ospush"         // 厂商通道优先
+	DistributionJPush         = "jpush"          // 极光通道优先
+	DistributionSecondaryPush = "secondary_push" // 极光通道优先，厂商通道补发
+	DistributionFirstOSPush   = "first_ospush"   // 厂商通道优先，失败时走极光通道
+)
+
+// 定义 fcm + 国内厂商组合时的下发逻辑(distribution_fcm)
+const (
+	DistributionFCMJPush     = "jpush"              // 极光通道
+	DistributionFCMFCM       = "fcm"                // fcm 通道
+	DistributionFCMPNS       = "pns"                // 国内厂商通道
+	DistributionFCMSecondary = "secondary_fcm_push" // fcm 通道优先，国内厂商通道补发
+)
+
+// 各厂商通道支持的字段(distribution 所有厂商均支持)
+var channelFieldVendors = map[string][]ChannelVendor{
+	"distribution_fcm": {VendorXiaomi, VendorHuawei, VendorHonor, VendorOPPO, VendorVivo, VendorMeizu, VendorFCM},
+	"channel_id":       {VendorXiaomi, VendorHuawei, VendorOPPO, VendorVivo, VendorFCM},
+	"classification":   {VendorVivo},
+	"importance":       {VendorHuawei, VendorHonor},
+	"large_icon":       {VendorXiaomi, VendorHuawei, VendorHonor, VendorOPPO},
+	"small_icon_uri":   {VendorXiaomi, VendorHuawei, VendorHonor},
+	"skip_quota":       {VendorXiaomi, VendorHuawei, VendorHonor, VendorOPPO, VendorVivo},
+}
+
+// NewThirdPartyChannel 创建厂商通道参数实例
+func NewThirdPartyChannel() *ThirdPartyChannel {
+	return new(ThirdPartyChannel)
+}
+
+// ThirdPartyChannel 厂商通道参数
+type ThirdPartyChannel struct {
+	Xiaomi *VendorChannel `json:"xiaomi,omitempty"`
+	Huawei *VendorChannel `json:"huawei,omitempty"`
+	Honor  *VendorChannel `json:"honor,omitempty"`
+	OPPO   *VendorChannel `json:"oppo,omitempty"`
+	Vivo   *VendorChannel `json:"vivo,omitempty"`
+	Meizu  *VendorChannel `json:"meizu,omitempty"`
+	FCM    *VendorChannel `json:"fcm,omitempty"`
+}
+
+// SetXiaomi 小米通道参数
+func (c *ThirdPartyChannel) SetXiaomi(ch *VendorChannel) *ThirdPartyChannel {
+	c.Xiaomi = ch
+	return c
+}
+
+// SetHuawei 华为通道参数
+func (c *ThirdPartyChannel) SetHuawei(ch *VendorChannel) *ThirdPartyChannel {
+	c.Huawei = ch
+	return c
+}
+
+// SetHonor 荣耀通道参数
+func (c *ThirdPartyChannel) SetHonor(ch *VendorChannel) *ThirdPartyChannel {
+	c.Honor = ch
+	return c
+}
+
+// SetOPPO OPPO 通道参数
+func (c *ThirdPartyChannel) SetOPPO(ch *VendorChannel) *ThirdPartyChannel {
+	c.OPPO = ch
+	return c
+}
+
+// SetVivo vivo 通道参数
+func (c *ThirdPartyChannel) SetVivo(ch *VendorChannel) *ThirdPartyChannel {
+	c.Vivo = ch
+	return c
+}
+
+// SetMeizu 魅族通道参数
+func (c *ThirdPartyChannel) SetMeizu(ch *VendorChannel) *ThirdPartyChannel {
+	c.Meizu = ch
+	return c
+}
+
+// SetFCM FCM 通道参数
+func (c *ThirdPartyChannel) SetFCM(ch *VendorChannel) *ThirdPartyChannel {
+	c.FCM = ch
+	return c
+}
+
+// Validate 校验各厂商通道参数，厂商不支持的字段返回 ErrInvalidOptions
+func (c *ThirdPartyChannel) Validate() error {
+	vendors := []struct {
+		vendor ChannelVendor
+		ch     *VendorChannel
+	}{
+		{VendorXiaomi, c.Xiaomi},
+		{VendorHuawei, c.Huawei},
+		{VendorHonor, c.Honor},
+		{VendorOPPO, c.OPPO},
+		{VendorVivo, c.Vivo},
+		{VendorMeizu, c.Meizu},
+		{VendorFCM, c.FCM},
+	}
+
+	for _, v := range vendors {
+		if v.ch == nil {
+			continue
+		}
+		if err := v.ch.validate(v.vendor); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// NewVendorChannel 创建单个厂商通道参数实例
+func NewVendorChannel() *VendorChannel {
+	return new(VendorChannel)
+}
+
+// VendorChannel 单个厂商通道参数
+type VendorChannel struct {
+	Distribution    string `json:"distribution,omitempty"`
+	DistributionFCM string `json:"distribution_fcm,omitempty"`
+	ChannelID       string `json:"channel_id,omitempty"`
+	Classification  int    `json:"classification,omitempty"`
+	Importance      string `json:"importance,omitempty"`
+	LargeIcon       string `json:"large_icon,omitempty"`
+	SmallIconURI    string `json:"small_icon_uri,omitempty"`
+	SkipQuota       bool   `json:"skip_quota,omitempty"`
+}
+
+// SetDistribution 通知栏消息下发逻辑
+func (v *VendorChannel) SetDistribution(distribution string) *VendorChannel {
+	v.Distribution = distribution
+	return v
+}
+
+// SetDistributionFCM fcm + 国内厂商组合时的下发逻辑
+func (v *VendorChannel) SetDistributionFCM(distributionFCM string) *VendorChannel {
+	v.DistributionFCM = distributionFCM
+	return v
+}
+
+// SetChannelID 厂商通知渠道 ID
+func (v *VendorChannel) SetChannelID(channelID string) *VendorChannel {
+	v.ChannelID = channelID
+	return v
+}
+
+// SetClassification 消息分类(0：运营消息，1：系统消息)
+func (v *VendorChannel) SetClassification(classification int) *VendorChannel {
+	v.Classification = classification
+	return v
+}
+
+// SetImportance 通知重要等级(LOW、NORMAL、HIGH)
+func (v *VendorChannel) SetImportance(importance string) *VendorChannel {
+	v.Importance = importance
+	return v
+}
+
+// SetLargeIcon 厂商通道通知栏大图标
+func (v *VendorChannel) SetLargeIcon(largeIcon string) *VendorChannel {
+	v.LargeIcon = largeIcon
+	return v
+}
+
+// SetSmallIconURI 厂商通道通知栏小图标
+func (v *VendorChannel) SetSmallIconURI(smallIconURI string) *VendorChannel {
+	v.SmallIconURI = smallIconURI
+	return v
+}
+
+// SetSkipQuota 是否跳过极光配额控制(按厂商配额下发)
+func (v *VendorChannel) SetSkipQuota(skipQuota bool) *VendorChannel {
+	v.SkipQuota = skipQuota
+	return v
+}
+
+func (v *VendorChannel) validate(vendor ChannelVendor) error {
+	switch v.Distribution {
+	case "", DistributionOSPush, DistributionJPush, DistributionSecondaryPush, DistributionFirstOSPush:
+	default:
+		return fmt.Errorf("%w: %s: unknown distribution %q", ErrInvalidOptions, vendor, v.Distribution)
+	}
+
+	switch v.DistributionFCM {
+	case "", DistributionFCMJPush, DistributionFCMFCM, DistributionFCMPNS, DistributionFCMSecondary:
+	default:
+		return fmt.Errorf("%w: %s: unknown distribution_fcm %q", ErrInvalidOptions, vendor, v.DistributionFCM)
+	}
+
+	switch v.Importance {
+	case "", "LOW", "NORMAL", "HIGH":
+	default:
+		return fmt.Errorf("%w: %s: unknown importance %q", ErrInvalidOptions, vendor, v.Importance)
+	}
+
+	if v.Classification != 0 && v.Classification != 1 {
+		return fmt.Errorf("%w: %s: classification must be 0 or 1", ErrInvalidOptions, vendor)
+	}
+
+	fields := []struct {
+		name string
+		set  bool
+	}{
+		{"distribution_fcm", v.DistributionFCM != ""},
+		{"channel_id", v.ChannelID != ""},
+		{"classification", v.Classification != 0},
+		{"importance", v.Importance != ""},
+		{"large_icon", v.LargeIcon != ""},
+		{"small_icon_uri", v.SmallIconURI != ""},
+		{"skip_quota", v.SkipQuota},
+	}
+	for _, field := range fields {
+		if field.set && !vendorSupports(field.name, vendor) {
+			return fmt.Errorf("%w: %s is not supported by %s", ErrInvalidOptions, field.name, vendor)
+		}
+	}
+	return nil
+}
+
+func vendorSupports(field string, vendor ChannelVendor) bool {
+	for _, v := range channelFieldVendors[field] {
+		if v == vendor {
+			return true
+		}
+	}
+	return false
+}
@@ -19,15 +19,14 @@ var (
 
 // NewCIDClient 创建获取CID实例
 func NewCIDClient(count int, opts ...Option) *CIDClient {
-	o := defaultOptions
-	for _, opt := range opts {
-		opt(&o)
-	}
+	return newCIDClient(newOptions(opts...), count)
+}
 
+func newCIDClient(opts *options, count int) *CIDClient {
 	return &CIDClient{
-		opts:         &o,
-		pushItem:     newCIDItem(&o, "push", count),
-		scheduleItem: newCIDItem(&o, "schedule", count),
+		opts:         opts,
+		pushItem:     newCIDItem(opts, "push", count),
+		scheduleItem: newCIDItem(opts, "schedule", count),
 	}
 }
 
@@ -99,10 +98,10 @@ func (c *cidItem) Get(ctx context.Context) (string, error) {
 	if len(result.CIDList) > 0 {
 		c.expiredAt = time.Now().Add(time.Hour * 23)
 		c.list = c.list.Init()
-		for _, v := range result.CIDList {
+		for _, v := range result.CIDList[1:] {
 			c.list.PushBack(v)
 		}
-		return c.list.Front().Value.(string), nil
+		return result.CIDList[0], nil
 	}
 
 	return "", ErrInvalidCID
@@ -0,0 +1,33 @@
+package jpush
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestCIDClient(t *testing.T) {
+	Convey("test cid client", t, func() {
+		var requests int
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			requests++
+			w.Write([]byte(`{"cidlist":["c-1","c-2"]}`))
+		}))
+		defer srv.Close()
+
+		cli := NewCIDClient(2, SetHost(srv.URL), SetAppKey(appKey), SetMasterSecret(masterSecret))
+		ctx := context.Background()
+
+		// 新获取的列表中的第一个 cid 不再重复返回
+		first, err := cli.GetPushID(ctx)
+		So(err, ShouldBeNil)
+		second, err := cli.GetPushID(ctx)
+		So(err, ShouldBeNil)
+		So(first, ShouldEqual, "c-1")
+		So(second, ShouldEqual, "c-2")
+		So(requests, ShouldEqual, 1)
+	})
+}
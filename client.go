@@ -3,46 +3,215 @@ package jpush
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/LyricTian/queue"
 )
 
-// NewClient 创建推送客户端实例
+var (
+	// ErrClientClosed 客户端已关闭
+	ErrClientClosed = errors.New("client closed")
+)
+
+// NewClient 创建推送客户端实例(持久化队列打开失败时推送返回该错误，可通过 Err 获取)
 func NewClient(maxThread int, opts ...Option) *Client {
-	o := defaultOptions
-	for _, opt := range opts {
-		opt(&o)
+	return newClient(maxThread, newOptions(opts...))
+}
+
+// NewClientWithQueue 创建启用持久化队列(SetQueueDir)的推送客户端实例，持久化队列打开失败时返回错误
+func NewClientWithQueue(maxThread int, opts ...Option) (*Client, error) {
+	cli := newClient(maxThread, newOptions(opts...))
+	if err := cli.Err(); err != nil {
+		cli.Terminate()
+		return nil, err
 	}
+	return cli, nil
+}
 
+func newClient(maxThread int, o *options) *Client {
 	cli := &Client{
-		opts:      &o,
+		opts:      o,
 		queue:     queue.NewListQueue(maxThread),
-		cidClient: NewCIDClient(o.cidCount, opts...),
+		cidClient: newCIDClient(o, o.cidCount),
+		pending:   make(map[pendingJob]struct{}),
 	}
 
+	cli.scheduleClient = newScheduleClient(cli.opts, cli.cidClient)
 	cli.jobPool = &sync.Pool{
 		New: func() interface{} {
-			return newPushJob(cli.opts, cli.queue, cli.cidClient)
+			return newPushJob(cli.opts, cli.enqueue, cli.cidClient, cli.wal, cli.release)
 		},
 	}
 	cli.queue.Run()
 
+	if o.queueDir != "" {
+		cli.replay()
+	}
+
 	return cli
 }
 
 // Client 推送客户端
 type Client struct {
-	opts      *options
-	queue     queue.Queuer
-	cidClient *CIDClient
-	jobPool   *sync.Pool
+	opts           *options
+	queue          queue.Queuer
+	cidClient      *CIDClient
+	scheduleClient *ScheduleClient
+	jobPool        *sync.Pool
+	wal            *pushWAL
+	walErr         error
+	lock           sync.Mutex
+	closed         bool
+	pending        map[pendingJob]struct{}
+	idle           chan struct{}
+	termLock       sync.RWMutex
+	terminated     bool
+	terminateOnce  sync.Once
+	withdrawn      withdrawnSet
 }
 
-// Terminate 终止客户端
+// Err 返回持久化队列打开失败的错误
+func (c *Client) Err() error {
+	return c.walErr
+}
+
+// replay 打开持久化队列，并将尚未完成的推送(沿用已分配的推送唯一标识符)重新放入队列
+func (c *Client) replay() {
+	wal, entries, err := openPushWAL(c.opts.queueDir)
+	if err != nil {
+		c.walErr = err
+		return
+	}
+	c.wal = wal
+
+	callback := c.opts.replayHandle
+	if callback == nil {
+		callback = func(context.Context, *PushResult, error) {}
+	}
+
+	for _, entry := range entries {
+		payload := new(Payload)
+		if err := json.Unmarshal(entry.payload, payload); err != nil {
+			wal.Ack(entry.id)
+			continue
+		}
+		payload.CID = entry.cid
+
+		c.lock.Lock()
+		job := c.newJob(context.Background(), payload, callback, entry.id)
+		c.lock.Unlock()
+
+		c.enqueue(job)
+	}
+}
+
+// enqueue 将任务放入队列，客户端已终止时返回 false
+func (c *Client) enqueue(job queue.Jober) bool {
+	c.termLock.RLock()
+	defer c.termLock.RUnlock()
+
+	if c.terminated {
+		return false
+	}
+	c.queue.Push(job)
+	return true
+}
+
+// Terminate 终止客户端(重复调用无影响)，等待重试的推送以 ErrClientClosed 结束
 func (c *Client) Terminate() {
-	c.queue.Terminate()
+	c.terminate(false)
+}
+
+// terminate 标记客户端已终止后关闭队列及持久化队列，async 为 true 时不等待正在执行的推送
+func (c *Client) terminate(async bool) {
+	c.terminateOnce.Do(func() {
+		c.lock.Lock()
+		c.closed = true
+		c.lock.Unlock()
+
+		// 标记后不再有任务放入队列，关闭队列时无需持有 termLock
+		c.termLock.Lock()
+		c.terminated = true
+		c.termLock.Unlock()
+
+		teardown := func() {
+			c.queue.Terminate()
+			if c.wal != nil {
+				c.wal.Close()
+			}
+		}
+		if async {
+			go teardown()
+			return
+		}
+		teardown()
+	})
+}
+
+// Shutdown 优雅关闭客户端：拒绝新的推送，等待队列中及正在执行的推送(含回调)完成后终止客户端，
+// ctx 结束时立即返回 *ShutdownError(包含仍未完成的推送)，不再等待正在执行的推送
+func (c *Client) Shutdown(ctx context.Context) error {
+	c.lock.Lock()
+	c.closed = true
+	if c.idle == nil {
+		c.idle = make(chan struct{})
+		if len(c.pending) == 0 {
+			close(c.idle)
+		}
+	}
+	idle := c.idle
+	c.lock.Unlock()
+
+	select {
+	case <-idle:
+		c.Terminate()
+		return nil
+	case <-ctx.Done():
+	}
+
+	c.lock.Lock()
+	e := &ShutdownError{Err: ctx.Err()}
+	for job := range c.pending {
+		e.Pending = append(e.Pending, job.pendingPayloads()...)
+	}
+	c.lock.Unlock()
+
+	c.terminate(true)
+	return e
+}
+
+// release 推送完成(回调已执行)后移出待完成集合
+func (c *Client) release(job pendingJob) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	delete(c.pending, job)
+	if c.idle != nil && len(c.pending) == 0 {
+		select {
+		case <-c.idle:
+		default:
+			close(c.idle)
+		}
+	}
+}
+
+// ShutdownError 关闭超时时仍未完成的推送
+type ShutdownError struct {
+	Pending []*Payload
+	Err     error
+}
+
+func (e *ShutdownError) Error() string {
+	return fmt.Sprintf("shutdown: %d pushes not completed: %v", len(e.Pending), e.Err)
+}
+
+// Unwrap 返回导致关闭超时的错误
+func (e *ShutdownError) Unwrap() error {
+	return e.Err
 }
 
 // GetPushID 获取推送ID
@@ -57,12 +226,118 @@ func (c *Client) GetScheduleID(ctx context.Context) (string, error) {
 
 // Push 消息推送
 func (c *Client) Push(ctx context.Context, payload *Payload, callback PushResultHandle) error {
+	if c.walErr != nil {
+		return c.walErr
+	}
+
+	if err := payload.Validate(); err != nil {
+		return err
+	}
+
+	c.lock.Lock()
+	closed := c.closed
+	c.lock.Unlock()
+	if closed {
+		return ErrClientClosed
+	}
+
+	// 写入持久化队列(含磁盘同步)不占用 c.lock
+	var walID uint64
+	if c.wal != nil {
+		id, err := c.wal.Enqueue(payload)
+		if err != nil {
+			return err
+		}
+		walID = id
+	}
+
+	// 持有 termLock 直至放入队列，避免与 Terminate 交错
+	c.termLock.RLock()
+	defer c.termLock.RUnlock()
+
+	c.lock.Lock()
+	if c.closed {
+		c.lock.Unlock()
+		if walID != 0 {
+			c.wal.Ack(walID)
+		}
+		return ErrClientClosed
+	}
+	job := c.newJob(ctx, payload, callback, walID)
+	c.lock.Unlock()
+
+	c.queue.Push(job)
+	return nil
+}
+
+// newJob 创建推送任务并记入待完成集合(调用方需持有 c.lock)
+func (c *Client) newJob(ctx context.Context, payload *Payload, callback PushResultHandle, walID uint64) *pushJob {
 	job := c.jobPool.Get().(*pushJob)
 	job.Reset(ctx, payload, callback)
-	c.queue.Push(job)
+	job.walID = walID
+	c.pending[job] = struct{}{}
+	return job
+}
+
+// PushAsync 异步推送，返回推送结果句柄
+func (c *Client) PushAsync(ctx context.Context, payload *Payload) *PushFuture {
+	future := newPushFuture()
+	err := c.Push(ctx, payload, future.resolve)
+	if err != nil {
+		future.resolve(ctx, nil, err)
+	}
+	return future
+}
+
+// PushSync 同步推送，阻塞等待推送结果
+func (c *Client) PushSync(ctx context.Context, payload *Payload) (*PushResult, error) {
+	return c.PushAsync(ctx, payload).Wait(ctx)
+}
+
+// RequeueDeadLetters 将推送失败的任务重新放入推送队列(沿用原推送唯一标识符)
+func (c *Client) RequeueDeadLetters(ctx context.Context, letters []*DeadLetter, callback PushResultHandle) error {
+	for _, letter := range letters {
+		payload := letter.Payload
+		if payload == nil {
+			continue
+		}
+		if letter.CID != "" {
+			payload.CID = letter.CID
+		}
+
+		err := c.Push(ctx, payload, callback)
+		if err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// Withdraw 撤回推送消息，已通过该客户端撤回的消息直接返回 ErrWithdrawRepeated
+func (c *Client) Withdraw(ctx context.Context, msgID string) error {
+	if c.withdrawn.has(msgID) {
+		return &WithdrawError{MsgID: msgID, reason: ErrWithdrawRepeated}
+	}
+
+	err := withdraw(ctx, c.opts, msgID)
+	if err != nil {
+		return err
+	}
+	c.withdrawn.add(msgID)
+	return nil
+}
+
+// WithdrawResult 按推送结果撤回推送消息，超出可撤回时间时直接返回 ErrWithdrawExpired
+func (c *Client) WithdrawResult(ctx context.Context, result *PushResult) error {
+	if result == nil {
+		return ErrInvalidMsgID
+	}
+	if !result.PushedAt.IsZero() && time.Since(result.PushedAt) > withdrawWindow {
+		return &WithdrawError{MsgID: result.MsgID, reason: ErrWithdrawExpired}
+	}
+	return c.Withdraw(ctx, result.MsgID)
+}
+
 // PushValidate 先校验，再推送
 func (c *Client) PushValidate(ctx context.Context, payload *Payload, callback PushResultHandle) error {
 	resp, err := pushRequest(ctx, c.opts, "/v3/push/validate", http.MethodPost, payload.Reader())
@@ -74,10 +349,42 @@ func (c *Client) PushValidate(ctx context.Context, payload *Payload, callback Pu
 	return c.Push(ctx, payload, callback)
 }
 
+// Quota 获取各接口最近一次响应的频率限制信息
+func (c *Client) Quota() map[string]*QuotaItem {
+	return c.opts.limiter.Snapshot()
+}
+
+// CreateSchedule 创建定时任务
+func (c *Client) CreateSchedule(ctx context.Context, schedule *Schedule) (*ScheduleResult, error) {
+	return c.scheduleClient.Create(ctx, schedule)
+}
+
+// ListSchedules 获取有效的定时任务列表
+func (c *Client) ListSchedules(ctx context.Context, page int) (*ScheduleList, error) {
+	return c.scheduleClient.List(ctx, page)
+}
+
+// GetSchedule 获取定时任务详情
+func (c *Client) GetSchedule(ctx context.Context, scheduleID string) (*Schedule, error) {
+	return c.scheduleClient.Get(ctx, scheduleID)
+}
+
+// UpdateSchedule 更新定时任务
+func (c *Client) UpdateSchedule(ctx context.Context, scheduleID string, schedule *Schedule) (*Schedule, error) {
+	return c.scheduleClient.Update(ctx, scheduleID, schedule)
+}
+
+// DeleteSchedule 删除定时任务
+func (c *Client) DeleteSchedule(ctx context.Context, scheduleID string) error {
+	return c.scheduleClient.Delete(ctx, scheduleID)
+}
+
 // PushResult 推送响应结果
 type PushResult struct {
-	SendNO string `json:"sendno"`
-	MsgID  string `json:"msg_id"`
+	SendNO     string      `json:"sendno"`
+	MsgID      string      `json:"msg_id"`
+	HeaderItem *HeaderItem `json:"-"`
+	PushedAt   time.Time   `json:"-"`
 }
 
 func (r *PushResult) String() string {
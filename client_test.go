@@ -2,9 +2,15 @@ package jpush
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
 	"strconv"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/LyricTian/queue"
 	. "github.com/smartystreets/goconvey/convey"
 )
 
@@ -77,3 +83,107 @@ func TestPushValidate(t *testing.T) {
 		cli.Terminate()
 	})
 }
+
+func TestShutdown(t *testing.T) {
+	Convey("test client shutdown", t, func() {
+		cli := NewClient(1,
+			SetAppKey(appKey),
+			SetMasterSecret(masterSecret),
+		)
+
+		payload := &Payload{
+			Platform: NewPlatform().All(),
+			Audience: NewAudience().All(),
+		}
+		errs := make(chan error, 1)
+		cli.lock.Lock()
+		job := cli.newJob(context.Background(), payload, func(_ context.Context, _ *PushResult, err error) {
+			errs <- err
+		}, 0)
+		cli.lock.Unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+
+		err := cli.Shutdown(ctx)
+		So(err, ShouldHaveSameTypeAs, &ShutdownError{})
+		So(err.(*ShutdownError).Pending, ShouldResemble, []*Payload{payload})
+		So(errors.Is(err, context.DeadlineExceeded), ShouldBeTrue)
+
+		So(cli.Push(context.Background(), payload, nil), ShouldEqual, ErrClientClosed)
+
+		// 关闭超时后，等待重试的任务不再放入已终止的队列
+		job.requeue(time.Millisecond)
+		So(<-errs, ShouldEqual, ErrClientClosed)
+		So(cli.Shutdown(context.Background()), ShouldBeNil)
+		cli.Terminate()
+	})
+}
+
+// waitQueue 关闭时等待正在执行的任务完成的队列
+type waitQueue struct {
+	queue.Queuer
+	wg sync.WaitGroup
+}
+
+type waitJob struct {
+	queue.Jober
+	wg *sync.WaitGroup
+}
+
+func (j *waitJob) Job() {
+	defer j.wg.Done()
+	j.Jober.Job()
+}
+
+func (q *waitQueue) Push(job queue.Jober) {
+	q.wg.Add(1)
+	q.Queuer.Push(&waitJob{Jober: job, wg: &q.wg})
+}
+
+func (q *waitQueue) Terminate() {
+	q.wg.Wait()
+	q.Queuer.Terminate()
+}
+
+func TestShutdownDeadline(t *testing.T) {
+	Convey("test client shutdown returns at deadline while pushing", t, func() {
+		block := make(chan struct{})
+		started := make(chan struct{}, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			started <- struct{}{}
+			<-block
+			w.Write([]byte(`{"sendno":"0","msg_id":"m-1"}`))
+		}))
+		defer srv.Close()
+
+		cli := NewClient(1, SetHost(srv.URL), SetAppKey(appKey), SetMasterSecret(masterSecret))
+		cli.queue = &waitQueue{Queuer: cli.queue}
+
+		payload := &Payload{
+			Platform: NewPlatform().All(),
+			Audience: NewAudience().All(),
+			CID:      "c-1",
+		}
+		results := make(chan *PushResult, 1)
+		err := cli.Push(context.Background(), payload, func(_ context.Context, result *PushResult, _ error) {
+			results <- result
+		})
+		So(err, ShouldBeNil)
+		<-started
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+		defer cancel()
+
+		start := time.Now()
+		err = cli.Shutdown(ctx)
+		So(time.Since(start), ShouldBeLessThan, time.Millisecond*500)
+		So(err, ShouldHaveSameTypeAs, &ShutdownError{})
+		So(err.(*ShutdownError).Pending, ShouldResemble, []*Payload{payload})
+
+		// 正在执行的推送完成后仍执行回调
+		close(block)
+		So((<-results).MsgID, ShouldEqual, "m-1")
+		So(cli.Shutdown(context.Background()), ShouldBeNil)
+	})
+}
@@ -0,0 +1,130 @@
+package jpush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"sync"
+	"time"
+)
+
+// DeadLetterSink 推送失败任务的接收器(重试耗尽或不可重试的推送)
+type DeadLetterSink interface {
+	Put(ctx context.Context, letter *DeadLetter) error
+}
+
+// DeadLetter 推送失败的任务
+type DeadLetter struct {
+	Payload   *Payload       `json:"payload"`    // 推送载荷
+	CID       string         `json:"cid"`        // 推送唯一标识符
+	Attempts  []*PushAttempt `json:"attempts"`   // 尝试记录
+	CreatedAt time.Time      `json:"created_at"` // 创建时间
+}
+
+// LastError 最后一次尝试的错误
+func (l *DeadLetter) LastError() error {
+	if len(l.Attempts) == 0 {
+		return nil
+	}
+	return l.Attempts[len(l.Attempts)-1].Err()
+}
+
+func newPushAttempt(err error) *PushAttempt {
+	attempt := &PushAttempt{
+		Time:    time.Now(),
+		Message: err.Error(),
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		attempt.StatusCode = e.StatusCode
+		attempt.ErrorItem = e.ErrorItem
+		attempt.HeaderItem = e.HeaderItem
+	}
+	return attempt
+}
+
+// PushAttempt 推送尝试记录
+type PushAttempt struct {
+	Time       time.Time   `json:"time"`
+	StatusCode int         `json:"status_code,omitempty"`
+	ErrorItem  *ErrorItem  `json:"error,omitempty"`
+	HeaderItem *HeaderItem `json:"header,omitempty"`
+	Message    string      `json:"message"`
+}
+
+// Err 还原尝试的错误，接口错误还原为 *Error
+func (a *PushAttempt) Err() error {
+	if a.StatusCode != 0 {
+		return &Error{
+			StatusCode: a.StatusCode,
+			ErrorItem:  a.ErrorItem,
+			HeaderItem: a.HeaderItem,
+		}
+	}
+	return errors.New(a.Message)
+}
+
+// NewFileDeadLetterSink 创建以 JSON Lines 格式追加写入文件的接收器
+func NewFileDeadLetterSink(name string) (*FileDeadLetterSink, error) {
+	file, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileDeadLetterSink{
+		file: file,
+	}, nil
+}
+
+// FileDeadLetterSink JSON Lines 文件接收器
+type FileDeadLetterSink struct {
+	lock sync.Mutex
+	file *os.File
+}
+
+// Put 写入推送失败的任务
+func (s *FileDeadLetterSink) Put(_ context.Context, letter *DeadLetter) error {
+	buf, err := json.Marshal(letter)
+	if err != nil {
+		return err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, err = s.file.Write(append(buf, '\n'))
+	return err
+}
+
+// Close 关闭文件
+func (s *FileDeadLetterSink) Close() error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	return s.file.Close()
+}
+
+// ReadDeadLetters 从 JSON Lines 文件中读取推送失败的任务
+func ReadDeadLetters(name string) ([]*DeadLetter, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var letters []*DeadLetter
+	decoder := json.NewDecoder(file)
+	for {
+		letter := new(DeadLetter)
+		err := decoder.Decode(letter)
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return letters, err
+		}
+		letters = append(letters, letter)
+	}
+	return letters, nil
+}
@@ -0,0 +1,106 @@
+package jpush
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFileDeadLetterSink(t *testing.T) {
+	Convey("test file dead letter sink", t, func() {
+		dir, err := os.MkdirTemp("", "jpush")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		name := filepath.Join(dir, "dead_letters.jsonl")
+		sink, err := NewFileDeadLetterSink(name)
+		So(err, ShouldBeNil)
+
+		payload := &Payload{
+			Platform:     NewPlatform().Add(Android, IOS),
+			Audience:     NewAudience().SetAlias("lyric"),
+			Notification: NewNotification().SetAlert("推送通知测试"),
+			CID:          "cid-1",
+		}
+		err = sink.Put(context.Background(), &DeadLetter{
+			Payload: payload,
+			CID:     payload.CID,
+			Attempts: []*PushAttempt{
+				newPushAttempt(errors.New("connection refused")),
+				newPushAttempt(&Error{StatusCode: 429, HeaderItem: &HeaderItem{XRateLimitReset: 5}}),
+			},
+			CreatedAt: time.Now(),
+		})
+		So(err, ShouldBeNil)
+		So(sink.Close(), ShouldBeNil)
+
+		letters, err := ReadDeadLetters(name)
+		So(err, ShouldBeNil)
+		So(letters, ShouldHaveLength, 1)
+		So(letters[0].CID, ShouldEqual, "cid-1")
+		So(letters[0].Payload.Platform.Value, ShouldResemble, []string{"android", "ios"})
+		So(letters[0].Payload.Audience.Value["alias"], ShouldResemble, []string{"lyric"})
+		So(letters[0].Attempts, ShouldHaveLength, 2)
+		So(letters[0].LastError().(*Error).StatusCode, ShouldEqual, 429)
+	})
+}
+
+type chanDeadLetterSink chan *DeadLetter
+
+func (s chanDeadLetterSink) Put(ctx context.Context, letter *DeadLetter) error {
+	s <- letter
+	return ctx.Err()
+}
+
+func TestPushDeadLetter(t *testing.T) {
+	Convey("test terminal push failures reach the dead letter sink", t, func() {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Write([]byte(`{"sendno":`))
+		}))
+		defer srv.Close()
+
+		sink := make(chanDeadLetterSink, 2)
+		cli := NewClient(1,
+			SetHost(srv.URL),
+			SetAppKey(appKey),
+			SetMasterSecret(masterSecret),
+			SetDeadLetterSink(sink),
+		)
+		defer cli.Terminate()
+
+		errs := make(chan error, 2)
+		callback := func(_ context.Context, _ *PushResult, err error) {
+			errs <- err
+		}
+		newPayload := func(cid string) *Payload {
+			return &Payload{
+				Platform: NewPlatform().All(),
+				Audience: NewAudience().All(),
+				CID:      cid,
+			}
+		}
+
+		// 响应无法解析
+		So(cli.Push(context.Background(), newPayload("cid-1"), callback), ShouldBeNil)
+		So(<-errs, ShouldNotBeNil)
+		letter := <-sink
+		So(letter.CID, ShouldEqual, "cid-1")
+		So(letter.Attempts, ShouldHaveLength, 1)
+
+		// ctx 已结束
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		So(cli.Push(ctx, newPayload("cid-2"), callback), ShouldBeNil)
+		So(errors.Is(<-errs, context.Canceled), ShouldBeTrue)
+		letter = <-sink
+		So(letter.CID, ShouldEqual, "cid-2")
+		So(letter.LastError(), ShouldNotBeNil)
+	})
+}
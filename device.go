@@ -0,0 +1,339 @@
+package jpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// 单次请求查询在线状态的注册 ID 的最大数量
+const deviceStatusBatchSize = 1000
+
+const deviceStatusEndpoint = "/v3/devices/status"
+
+var (
+	// ErrAudienceEmpty 推送目标中的注册 ID 已全部去掉
+	ErrAudienceEmpty = errors.New("audience is empty")
+)
+
+// NewDeviceClient 创建设备客户端实例
+func NewDeviceClient(opts ...Option) *DeviceClient {
+	return newDeviceClient(newOptions(opts...))
+}
+
+func newDeviceClient(opts *options) *DeviceClient {
+	return &DeviceClient{
+		opts: opts,
+	}
+}
+
+// DeviceClient 设备客户端(查询及设置设备的标签、别名与手机号)
+type DeviceClient struct {
+	opts *options
+}
+
+// Get 查询设备的标签、别名与手机号
+func (c *DeviceClient) Get(ctx context.Context, registrationID string) (*DeviceInfo, error) {
+	router := fmt.Sprintf("/v3/devices/%s", url.PathEscape(registrationID))
+	resp, err := deviceRequest(ctx, c.opts, router, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(DeviceInfo)
+	err = resp.JSON(result)
+	if err != nil {
+		return nil, err
+	}
+	result.HeaderItem = newHeaderItem(resp.Response().Header)
+	return result, nil
+}
+
+// Update 设置设备的标签、别名与手机号
+func (c *DeviceClient) Update(ctx context.Context, registrationID string, update *DeviceUpdate) error {
+	router := fmt.Sprintf("/v3/devices/%s", url.PathEscape(registrationID))
+	resp, err := deviceRequest(ctx, c.opts, router, http.MethodPost, update.Reader())
+	if err != nil {
+		return err
+	}
+	resp.Close()
+	return nil
+}
+
+// SetTags 将设备的标签设定为 tags(tags 为空时清空标签)
+func (c *DeviceClient) SetTags(ctx context.Context, registrationID string, tags ...string) error {
+	if len(tags) == 0 {
+		return c.ClearTags(ctx, registrationID)
+	}
+
+	info, err := c.Get(ctx, registrationID)
+	if err != nil {
+		return err
+	}
+
+	update := NewDeviceUpdate().
+		AddTags(diffStrings(tags, info.Tags)...).
+		RemoveTags(diffStrings(info.Tags, tags)...)
+	if update.IsEmpty() {
+		return nil
+	}
+	return c.Update(ctx, registrationID, update)
+}
+
+// AddTags 为设备添加标签
+func (c *DeviceClient) AddTags(ctx context.Context, registrationID string, tags ...string) error {
+	return c.Update(ctx, registrationID, NewDeviceUpdate().AddTags(tags...))
+}
+
+// RemoveTags 删除设备的标签
+func (c *DeviceClient) RemoveTags(ctx context.Context, registrationID string, tags ...string) error {
+	return c.Update(ctx, registrationID, NewDeviceUpdate().RemoveTags(tags...))
+}
+
+// ClearTags 清空设备的标签
+func (c *DeviceClient) ClearTags(ctx context.Context, registrationID string) error {
+	return c.Update(ctx, registrationID, NewDeviceUpdate().ClearTags())
+}
+
+// SetAlias 设定设备的别名
+func (c *DeviceClient) SetAlias(ctx context.Context, registrationID, alias string) error {
+	return c.Update(ctx, registrationID, NewDeviceUpdate().SetAlias(alias))
+}
+
+// ClearAlias 清除设备的别名
+func (c *DeviceClient) ClearAlias(ctx context.Context, registrationID string) error {
+	return c.Update(ctx, registrationID, NewDeviceUpdate().ClearAlias())
+}
+
+// SetMobile 绑定设备的手机号
+func (c *DeviceClient) SetMobile(ctx context.Context, registrationID, mobile string) error {
+	return c.Update(ctx, registrationID, NewDeviceUpdate().SetMobile(mobile))
+}
+
+// Status 查询设备的在线状态，按接口限制自动分批，部分批次失败时返回 *BulkError 及成功批次的结果
+func (c *DeviceClient) Status(ctx context.Context, registrationIDs ...string) (DeviceStatuses, error) {
+	statuses := make(DeviceStatuses)
+
+	var results []*BatchResult
+	for _, items := range chunkStrings(registrationIDs, deviceStatusBatchSize) {
+		result := &BatchResult{Items: items}
+		results = append(results, result)
+
+		if err := ctx.Err(); err != nil {
+			result.Err = err
+			continue
+		}
+
+		body, err := json.Marshal(map[string][]string{"registration_ids": items})
+		if err != nil {
+			result.Err = err
+			continue
+		}
+
+		var batch DeviceStatuses
+		result.Err = retryRequest(ctx, c.opts, deviceStatusEndpoint, func() error {
+			resp, err := deviceRequest(ctx, c.opts, deviceStatusEndpoint, http.MethodPost, bytes.NewReader(body))
+			if err != nil {
+				return err
+			}
+			return resp.JSON(&batch)
+		})
+		for id, status := range batch {
+			statuses[id] = status
+		}
+	}
+
+	return statuses, newBulkError(results)
+}
+
+// DeviceStatus 设备的在线状态
+type DeviceStatus struct {
+	Online         bool      // 是否在线
+	LastOnlineTime time.Time // 最后在线时间(离线时有效)
+}
+
+// UnmarshalJSON 实现 JSON 接口
+func (s *DeviceStatus) UnmarshalJSON(data []byte) error {
+	var v struct {
+		Online         bool   `json:"online"`
+		LastOnlineTime string `json:"last_online_time"`
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	s.Online = v.Online
+	s.LastOnlineTime = time.Time{}
+	if v.LastOnlineTime != "" {
+		t, err := time.ParseInLocation(serverTimeLayout, v.LastOnlineTime, serverLocation)
+		if err != nil {
+			return err
+		}
+		s.LastOnlineTime = t
+	}
+	return nil
+}
+
+// OfflineFor 设备截至 now 已离线的时长，在线时返回 0，最后在线时间未知时返回 -1
+func (s *DeviceStatus) OfflineFor(now time.Time) time.Duration {
+	if s.Online {
+		return 0
+	} else if s.LastOnlineTime.IsZero() {
+		return -1
+	}
+	return now.Sub(s.LastOnlineTime)
+}
+
+// DeviceStatuses 设备在线状态(按注册 ID)
+type DeviceStatuses map[string]*DeviceStatus
+
+// Prune 返回推送目标的副本，去掉其中离线超过 offline 的注册 ID(最后在线时间未知的离线设备同样去掉，未查询到状态的设备保留)，
+// 注册 ID 全部去掉时返回 ErrAudienceEmpty(仅删除该条件会扩大推送范围)
+func (s DeviceStatuses) Prune(audience *Audience, offline time.Duration) (*Audience, error) {
+	result := &Audience{IsAll: audience.IsAll, File: audience.File}
+	if audience.Value == nil {
+		return result, nil
+	}
+
+	now := time.Now()
+	result.Value = make(map[string][]string, len(audience.Value))
+	for key, values := range audience.Value {
+		if key != "registration_id" {
+			result.Value[key] = values
+			continue
+		}
+
+		var ids []string
+		for _, id := range values {
+			if status, ok := s[id]; ok {
+				if d := status.OfflineFor(now); d < 0 || d > offline {
+					continue
+				}
+			}
+			ids = append(ids, id)
+		}
+		if len(ids) == 0 {
+			return nil, ErrAudienceEmpty
+		}
+		result.Value[key] = ids
+	}
+	return result, nil
+}
+
+// DeviceInfo 设备的标签、别名与手机号
+type DeviceInfo struct {
+	Tags       []string    `json:"tags"`
+	Alias      string      `json:"alias"`
+	Mobile     string      `json:"mobile"`
+	HeaderItem *HeaderItem `json:"-"`
+}
+
+// NewDeviceUpdate 创建设备更新实例
+func NewDeviceUpdate() *DeviceUpdate {
+	return new(DeviceUpdate)
+}
+
+// DeviceUpdate 设备更新(未设定的字段保持不变)
+type DeviceUpdate struct {
+	addTags    []string
+	removeTags []string
+	clearTags  bool
+	alias      *string
+	mobile     *string
+}
+
+// AddTags 添加标签
+func (u *DeviceUpdate) AddTags(tags ...string) *DeviceUpdate {
+	u.addTags = append(u.addTags, tags...)
+	return u
+}
+
+// RemoveTags 删除标签
+func (u *DeviceUpdate) RemoveTags(tags ...string) *DeviceUpdate {
+	u.removeTags = append(u.removeTags, tags...)
+	return u
+}
+
+// ClearTags 清空标签(忽略添加及删除的标签)
+func (u *DeviceUpdate) ClearTags() *DeviceUpdate {
+	u.clearTags = true
+	return u
+}
+
+// SetAlias 设定别名
+func (u *DeviceUpdate) SetAlias(alias string) *DeviceUpdate {
+	u.alias = &alias
+	return u
+}
+
+// ClearAlias 清除别名
+func (u *DeviceUpdate) ClearAlias() *DeviceUpdate {
+	return u.SetAlias("")
+}
+
+// SetMobile 设定手机号
+func (u *DeviceUpdate) SetMobile(mobile string) *DeviceUpdate {
+	u.mobile = &mobile
+	return u
+}
+
+// IsEmpty 是否没有需要更新的字段
+func (u *DeviceUpdate) IsEmpty() bool {
+	return !u.clearTags && len(u.addTags) == 0 && len(u.removeTags) == 0 && u.alias == nil && u.mobile == nil
+}
+
+// MarshalJSON 实现 JSON 接口
+func (u *DeviceUpdate) MarshalJSON() ([]byte, error) {
+	v := make(map[string]interface{})
+
+	if u.clearTags {
+		v["tags"] = ""
+	} else if len(u.addTags) > 0 || len(u.removeTags) > 0 {
+		tags := make(map[string][]string)
+		if len(u.addTags) > 0 {
+			tags["add"] = u.addTags
+		}
+		if len(u.removeTags) > 0 {
+			tags["remove"] = u.removeTags
+		}
+		v["tags"] = tags
+	}
+
+	if u.alias != nil {
+		v["alias"] = *u.alias
+	}
+	if u.mobile != nil {
+		v["mobile"] = *u.mobile
+	}
+	return json.Marshal(v)
+}
+
+// Reader 序列化为 JSON 流
+func (u *DeviceUpdate) Reader() io.Reader {
+	buf := new(bytes.Buffer)
+	json.NewEncoder(buf).Encode(u)
+	return buf
+}
+
+// diffStrings 返回在 a 中但不在 b 中的元素
+func diffStrings(a, b []string) []string {
+	exists := make(map[string]bool, len(b))
+	for _, v := range b {
+		exists[v] = true
+	}
+
+	var result []string
+	for _, v := range a {
+		if !exists[v] {
+			exists[v] = true
+			result = append(result, v)
+		}
+	}
+	return result
+}
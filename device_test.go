@@ -0,0 +1,125 @@
+package jpush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestDeviceStatusesPrune(t *testing.T) {
+	Convey("test device statuses prune", t, func() {
+		recent := time.Now().Add(-time.Hour).In(serverLocation).Format(serverTimeLayout)
+		data := `{
+			"a": {"online": true},
+			"b": {"online": false, "last_online_time": "` + recent + `"},
+			"c": {"online": false, "last_online_time": "2014-12-16 10:57:07"},
+			"d": {"online": false}
+		}`
+
+		var statuses DeviceStatuses
+		So(json.Unmarshal([]byte(data), &statuses), ShouldBeNil)
+		So(statuses["c"].LastOnlineTime.Year(), ShouldEqual, 2014)
+
+		audience := NewAudience().SetRegistrationID("a", "b", "c", "d", "e").SetTag("vip")
+		pruned, err := statuses.Prune(audience, time.Hour*24*14)
+		So(err, ShouldBeNil)
+		So(pruned.Value["registration_id"], ShouldResemble, []string{"a", "b", "e"})
+		So(pruned.Value["tag"], ShouldResemble, []string{"vip"})
+		So(audience.Value["registration_id"], ShouldHaveLength, 5)
+
+		// 注册 ID 全部去掉
+		_, err = statuses.Prune(NewAudience().SetRegistrationID("c", "d").SetTag("vip"), time.Hour)
+		So(errors.Is(err, ErrAudienceEmpty), ShouldBeTrue)
+
+		pruned, err = statuses.Prune(NewAudience().SetFile("f-1"), time.Hour)
+		So(err, ShouldBeNil)
+		So(pruned.File.FileID, ShouldEqual, "f-1")
+
+		buf, err := json.Marshal(pruned)
+		So(err, ShouldBeNil)
+		So(string(buf), ShouldEqual, `{"file":{"file_id":"f-1"}}`)
+	})
+}
+
+func TestDeviceClient(t *testing.T) {
+	Convey("test device client", t, func() {
+		var (
+			paths []string
+			body  string
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.Method+" "+r.URL.Path)
+
+			switch r.Method {
+			case http.MethodGet:
+				w.Header().Set("X-Rate-Limit-Quota", "600")
+				w.Header().Set("X-Rate-Limit-Remaining", "599")
+				w.Header().Set("X-Rate-Limit-Reset", "60")
+				w.Write([]byte(`{"tags":["a","b"],"alias":"lyric","mobile":"13800138000"}`))
+			case http.MethodPost:
+				buf, _ := io.ReadAll(r.Body)
+				body = strings.TrimSpace(string(buf))
+			}
+		}))
+		defer srv.Close()
+
+		// 设备接口使用 SetDeviceHost 设定的地址
+		cli := NewDeviceClient(SetHost("http://127.0.0.1:0"), SetDeviceHost(srv.URL),
+			SetAppKey(appKey), SetMasterSecret(masterSecret))
+		ctx := context.Background()
+
+		info, err := cli.Get(ctx, "rid-1")
+		So(err, ShouldBeNil)
+		So(paths, ShouldResemble, []string{"GET /v3/devices/rid-1"})
+		So(info.Tags, ShouldResemble, []string{"a", "b"})
+		So(info.Alias, ShouldEqual, "lyric")
+		So(info.Mobile, ShouldEqual, "13800138000")
+		So(info.HeaderItem.XRateLimitRemaining, ShouldEqual, 599)
+
+		// 先查询当前标签，再提交差异
+		paths = nil
+		So(cli.SetTags(ctx, "rid-1", "b", "c"), ShouldBeNil)
+		So(paths, ShouldResemble, []string{"GET /v3/devices/rid-1", "POST /v3/devices/rid-1"})
+		So(body, ShouldEqual, `{"tags":{"add":["c"],"remove":["a"]}}`)
+
+		// 标签未变化时不提交
+		paths = nil
+		So(cli.SetTags(ctx, "rid-1", "b", "a"), ShouldBeNil)
+		So(paths, ShouldResemble, []string{"GET /v3/devices/rid-1"})
+
+		paths = nil
+		So(cli.SetTags(ctx, "rid-1"), ShouldBeNil)
+		So(paths, ShouldResemble, []string{"POST /v3/devices/rid-1"})
+		So(body, ShouldEqual, `{"tags":""}`)
+
+		So(cli.ClearTags(ctx, "rid-1"), ShouldBeNil)
+		So(body, ShouldEqual, `{"tags":""}`)
+
+		So(cli.AddTags(ctx, "rid-1", "d"), ShouldBeNil)
+		So(body, ShouldEqual, `{"tags":{"add":["d"]}}`)
+
+		So(cli.RemoveTags(ctx, "rid-1", "a"), ShouldBeNil)
+		So(body, ShouldEqual, `{"tags":{"remove":["a"]}}`)
+
+		So(cli.SetAlias(ctx, "rid-1", "lyric"), ShouldBeNil)
+		So(body, ShouldEqual, `{"alias":"lyric"}`)
+
+		So(cli.ClearAlias(ctx, "rid-1"), ShouldBeNil)
+		So(body, ShouldEqual, `{"alias":""}`)
+
+		So(cli.SetMobile(ctx, "rid-1", "13800138000"), ShouldBeNil)
+		So(body, ShouldEqual, `{"mobile":"13800138000"}`)
+
+		update := NewDeviceUpdate().AddTags("x").ClearTags().SetAlias("a1")
+		So(cli.Update(ctx, "rid-1", update), ShouldBeNil)
+		So(body, ShouldEqual, `{"alias":"a1","tags":""}`)
+	})
+}
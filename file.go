@@ -0,0 +1,155 @@
+package jpush
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+// FileType 推送目标文件的类型
+type FileType string
+
+func (t FileType) String() string {
+	return string(t)
+}
+
+// 定义推送目标文件的类型
+const (
+	FileRegistrationID FileType = "registration_id"
+	FileAlias          FileType = "alias"
+)
+
+// NewFileClient 创建文件客户端实例
+func NewFileClient(opts ...Option) *FileClient {
+	return newFileClient(newOptions(opts...))
+}
+
+func newFileClient(opts *options) *FileClient {
+	return &FileClient{
+		opts: opts,
+	}
+}
+
+// FileClient 文件客户端(上传推送目标文件，配合 Audience.SetFile 使用)
+type FileClient struct {
+	opts *options
+}
+
+// Upload 上传推送目标文件(每行一个注册 ID 或别名)，r 以流的方式写入请求
+func (c *FileClient) Upload(ctx context.Context, typ FileType, r io.Reader) (*FileResult, error) {
+	return c.upload(ctx, typ, func(w io.Writer) error {
+		_, err := io.Copy(w, r)
+		return err
+	})
+}
+
+// UploadRegistrationIDs 上传注册 ID 文件
+func (c *FileClient) UploadRegistrationIDs(ctx context.Context, registrationIDs []string) (*FileResult, error) {
+	return c.upload(ctx, FileRegistrationID, writeLines(registrationIDs))
+}
+
+// UploadAliases 上传别名文件
+func (c *FileClient) UploadAliases(ctx context.Context, aliases []string) (*FileResult, error) {
+	return c.upload(ctx, FileAlias, writeLines(aliases))
+}
+
+func (c *FileClient) upload(ctx context.Context, typ FileType, write func(io.Writer) error) (*FileResult, error) {
+	router := fmt.Sprintf("/v3/files/%s", typ)
+
+	result := new(FileResult)
+	header, err := multipartRequest(ctx, c.opts, router, http.MethodPost, func(mw *multipart.Writer) error {
+		w, err := mw.CreateFormFile("filename", typ.String()+".txt")
+		if err != nil {
+			return err
+		}
+		return write(w)
+	}, result)
+	if err != nil {
+		return nil, err
+	}
+	result.HeaderItem = header
+	return result, nil
+}
+
+// List 查询有效的文件列表
+func (c *FileClient) List(ctx context.Context) (*FileList, error) {
+	resp, err := pushRequest(ctx, c.opts, "/v3/files", http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(FileList)
+	err = resp.JSON(result)
+	if err != nil {
+		return nil, err
+	}
+	result.HeaderItem = newHeaderItem(resp.Response().Header)
+	return result, nil
+}
+
+// Get 查询文件详情
+func (c *FileClient) Get(ctx context.Context, fileID string) (*FileInfo, error) {
+	router := fmt.Sprintf("/v3/files/%s", url.PathEscape(fileID))
+	resp, err := pushRequest(ctx, c.opts, router, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(FileInfo)
+	err = resp.JSON(result)
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// Delete 删除文件
+func (c *FileClient) Delete(ctx context.Context, fileID string) error {
+	router := fmt.Sprintf("/v3/files/%s", url.PathEscape(fileID))
+	resp, err := pushRequest(ctx, c.opts, router, http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	resp.Close()
+	return nil
+}
+
+// writeLines 逐行写入，避免在内存中拼接完整的文件内容
+func writeLines(lines []string) func(io.Writer) error {
+	return func(w io.Writer) error {
+		bw := bufio.NewWriter(w)
+		for _, line := range lines {
+			if _, err := bw.WriteString(line); err != nil {
+				return err
+			}
+			if err := bw.WriteByte('\n'); err != nil {
+				return err
+			}
+		}
+		return bw.Flush()
+	}
+}
+
+// FileResult 上传文件响应结果
+type FileResult struct {
+	FileID     string      `json:"file_id"`
+	HeaderItem *HeaderItem `json:"-"`
+}
+
+// FileInfo 文件详情
+type FileInfo struct {
+	FileID     string `json:"file_id"`
+	Type       string `json:"type"`
+	CreateTime string `json:"create_time"`
+}
+
+// FileList 文件列表
+type FileList struct {
+	TotalCount int         `json:"total_count"`
+	Files      []*FileInfo `json:"files"`
+	HeaderItem *HeaderItem `json:"-"`
+}
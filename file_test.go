@@ -0,0 +1,63 @@
+package jpush
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestFileUpload(t *testing.T) {
+	Convey("test file upload", t, func() {
+		var (
+			path    string
+			content string
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			if user, _, ok := r.BasicAuth(); !ok || user != appKey {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			file, _, err := r.FormFile("filename")
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			buf, _ := io.ReadAll(file)
+			content = string(buf)
+
+			w.Header().Set("X-Rate-Limit-Quota", "600")
+			w.Header().Set("X-Rate-Limit-Remaining", "599")
+			w.Header().Set("X-Rate-Limit-Reset", "60")
+			w.Write([]byte(`{"file_id":"f-1"}`))
+		}))
+		defer srv.Close()
+
+		cli := NewFileClient(SetHost(srv.URL), SetAppKey(appKey), SetMasterSecret(masterSecret))
+		result, err := cli.UploadRegistrationIDs(context.Background(), []string{"a", "b"})
+		So(err, ShouldBeNil)
+		So(result.FileID, ShouldEqual, "f-1")
+		So(result.HeaderItem.XRateLimitRemaining, ShouldEqual, 599)
+		So(path, ShouldEqual, "/v3/files/registration_id")
+		So(content, ShouldEqual, "a\nb\n")
+
+		_, err = NewFileClient(SetHost(srv.URL)).UploadAliases(context.Background(), []string{"a"})
+		So(err, ShouldHaveSameTypeAs, &Error{})
+		So(err.(*Error).StatusCode, ShouldEqual, http.StatusUnauthorized)
+
+		audience := NewAudience().SetFile(result.FileID)
+		buf, err := json.Marshal(audience)
+		So(err, ShouldBeNil)
+		So(string(buf), ShouldEqual, `{"file":{"file_id":"f-1"}}`)
+
+		decoded := new(Audience)
+		So(json.Unmarshal(buf, decoded), ShouldBeNil)
+		So(decoded.File.FileID, ShouldEqual, "f-1")
+	})
+}
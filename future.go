@@ -0,0 +1,96 @@
+package jpush
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrPushPending 推送尚未完成
+	ErrPushPending = errors.New("push pending")
+)
+
+func newPushFuture() *PushFuture {
+	return &PushFuture{
+		done:    make(chan struct{}),
+		startAt: time.Now(),
+	}
+}
+
+// PushFuture 异步推送的结果句柄
+type PushFuture struct {
+	once    sync.Once
+	done    chan struct{}
+	result  *PushResult
+	err     error
+	startAt time.Time
+	endAt   time.Time
+}
+
+// resolve 设定推送结果(仅首次调用有效)，可作为 PushResultHandle 使用
+func (f *PushFuture) resolve(_ context.Context, result *PushResult, err error) {
+	f.once.Do(func() {
+		f.result = result
+		f.err = err
+		f.endAt = time.Now()
+		close(f.done)
+	})
+}
+
+// Done 推送完成时关闭的通道
+func (f *PushFuture) Done() <-chan struct{} {
+	return f.done
+}
+
+// Result 获取推送结果，推送尚未完成时返回 ErrPushPending
+func (f *PushFuture) Result() (*PushResult, error) {
+	select {
+	case <-f.done:
+		return f.result, f.err
+	default:
+		return nil, ErrPushPending
+	}
+}
+
+// Wait 阻塞等待推送结果
+func (f *PushFuture) Wait(ctx context.Context) (*PushResult, error) {
+	select {
+	case <-f.done:
+		return f.result, f.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// Duration 推送耗时，推送尚未完成时返回已等待的时长
+func (f *PushFuture) Duration() time.Duration {
+	select {
+	case <-f.done:
+		return f.endAt.Sub(f.startAt)
+	default:
+		return time.Since(f.startAt)
+	}
+}
+
+// FutureResult 推送结果汇总项
+type FutureResult struct {
+	Result   *PushResult
+	Err      error
+	Duration time.Duration
+}
+
+// WaitAll 等待全部推送完成并按顺序汇总结果，ctx 结束时未完成的推送记为 ctx.Err()
+func WaitAll(ctx context.Context, futures ...*PushFuture) []*FutureResult {
+	results := make([]*FutureResult, len(futures))
+	for i, f := range futures {
+		result, err := f.Wait(ctx)
+		results[i] = &FutureResult{
+			Result:   result,
+			Err:      err,
+			Duration: f.Duration(),
+		}
+	}
+	return results
+}
@@ -0,0 +1,110 @@
+package jpush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPushFuture(t *testing.T) {
+	Convey("test push future", t, func() {
+		f1, f2, f3 := newPushFuture(), newPushFuture(), newPushFuture()
+
+		_, err := f1.Result()
+		So(err, ShouldEqual, ErrPushPending)
+
+		f1.resolve(context.Background(), &PushResult{MsgID: "1"}, nil)
+		f2.resolve(context.Background(), nil, errors.New("failed"))
+
+		result, err := f1.Wait(context.Background())
+		So(err, ShouldBeNil)
+		So(result.MsgID, ShouldEqual, "1")
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*10)
+		defer cancel()
+
+		results := WaitAll(ctx, f1, f2, f3)
+		So(results, ShouldHaveLength, 3)
+		So(results[0].Result.MsgID, ShouldEqual, "1")
+		So(results[1].Err, ShouldNotBeNil)
+		So(results[2].Err, ShouldEqual, context.DeadlineExceeded)
+	})
+}
+
+func TestPushSync(t *testing.T) {
+	Convey("test push sync and async", t, func() {
+		var (
+			lock     sync.Mutex
+			requests []string
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var body struct {
+				CID string `json:"cid"`
+			}
+			json.NewDecoder(r.Body).Decode(&body)
+
+			lock.Lock()
+			requests = append(requests, r.URL.Path+" "+body.CID)
+			remaining := "599"
+			if len(requests) > 1 {
+				// 配额用尽，之后的推送由限制器暂停
+				remaining = "0"
+			}
+			lock.Unlock()
+
+			w.Header().Set("X-Rate-Limit-Quota", "600")
+			w.Header().Set("X-Rate-Limit-Remaining", remaining)
+			w.Header().Set("X-Rate-Limit-Reset", "60")
+			w.Write([]byte(`{"sendno":"0","msg_id":"m-` + body.CID + `"}`))
+		}))
+		defer srv.Close()
+
+		cli := NewClient(1, SetHost(srv.URL), SetAppKey(appKey), SetMasterSecret(masterSecret))
+		defer cli.Terminate()
+
+		newPayload := func(cid string) *Payload {
+			return &Payload{
+				Platform:     NewPlatform().All(),
+				Audience:     NewAudience().All(),
+				Notification: &Notification{Alert: "hello"},
+				CID:          cid,
+			}
+		}
+
+		future := cli.PushAsync(context.Background(), newPayload("c-1"))
+		result, err := future.Wait(context.Background())
+		So(err, ShouldBeNil)
+		So(result.MsgID, ShouldEqual, "m-c-1")
+		So(result.HeaderItem.XRateLimitRemaining, ShouldEqual, 599)
+		So(future.Duration(), ShouldBeGreaterThan, 0)
+
+		result, err = cli.PushSync(context.Background(), newPayload("c-2"))
+		So(err, ShouldBeNil)
+		So(result.MsgID, ShouldEqual, "m-c-2")
+
+		// 推送因频次超出限制仍在等待时，ctx 结束即返回
+		ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*20)
+		defer cancel()
+		start := time.Now()
+		_, err = cli.PushSync(ctx, newPayload("c-3"))
+		So(err, ShouldEqual, context.DeadlineExceeded)
+		So(time.Since(start), ShouldBeLessThan, time.Second)
+
+		lock.Lock()
+		So(requests, ShouldResemble, []string{"/v3/push c-1", "/v3/push c-2"})
+		lock.Unlock()
+
+		// 校验失败时直接返回已完成的结果句柄
+		invalid := newPayload("c-4")
+		invalid.Notification.IOS = NewIOSNotification().SetRelevanceScore(2)
+		_, err = cli.PushAsync(context.Background(), invalid).Result()
+		So(errors.Is(err, ErrInvalidNotification), ShouldBeTrue)
+	})
+}
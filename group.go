@@ -0,0 +1,92 @@
+package jpush
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// NewGroupClient 创建应用分组推送客户端实例
+func NewGroupClient(groupKey, groupMasterSecret string, opts ...Option) *GroupClient {
+	o := newOptions(opts...)
+	o.appKey = "group-" + groupKey
+	o.masterSecret = groupMasterSecret
+
+	return &GroupClient{
+		opts: o,
+	}
+}
+
+// GroupClient 应用分组推送客户端
+type GroupClient struct {
+	opts *options
+}
+
+// GroupPush 应用分组推送
+func (c *GroupClient) GroupPush(ctx context.Context, payload *Payload) (*GroupPushResult, error) {
+	return c.push(ctx, "/v3/grouppush", payload)
+}
+
+// GroupPushValidate 应用分组推送校验(不实际推送)
+func (c *GroupClient) GroupPushValidate(ctx context.Context, payload *Payload) (*GroupPushResult, error) {
+	return c.push(ctx, "/v3/grouppush/validate", payload)
+}
+
+func (c *GroupClient) push(ctx context.Context, router string, payload *Payload) (*GroupPushResult, error) {
+	if err := payload.Validate(); err != nil {
+		return nil, err
+	}
+
+	resp, err := pushRequest(ctx, c.opts, router, http.MethodPost, payload.Reader())
+	if err != nil {
+		return nil, err
+	}
+
+	var items map[string]json.RawMessage
+	err = resp.JSON(&items)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &GroupPushResult{
+		Results:    make(map[string]*PushResult),
+		Errors:     make(map[string]*ErrorItem),
+		HeaderItem: newHeaderItem(resp.Response().Header),
+	}
+	pushedAt := time.Now()
+
+	for key, raw := range items {
+		if key == "group_msgid" {
+			if err := json.Unmarshal(raw, &result.GroupMsgID); err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		var item struct {
+			PushResult
+			Error *ErrorItem `json:"error"`
+		}
+		if err := json.Unmarshal(raw, &item); err != nil {
+			return nil, err
+		}
+
+		if item.Error != nil {
+			result.Errors[key] = item.Error
+			continue
+		}
+		item.PushResult.PushedAt = pushedAt
+		result.Results[key] = &item.PushResult
+	}
+
+	return result, nil
+}
+
+// GroupPushResult 应用分组推送结果
+type GroupPushResult struct {
+	GroupMsgID string                 // 分组消息 ID
+	Results    map[string]*PushResult // 推送成功的应用(按 AppKey)
+	Errors     map[string]*ErrorItem  // 推送失败的应用(按 AppKey)
+	HeaderItem *HeaderItem            `json:"-"`
+}
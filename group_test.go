@@ -0,0 +1,58 @@
+package jpush
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestGroupPush(t *testing.T) {
+	Convey("test group push", t, func() {
+		var (
+			path string
+			user string
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			user, _, _ = r.BasicAuth()
+
+			w.Header().Set("X-Rate-Limit-Quota", "600")
+			w.Header().Set("X-Rate-Limit-Remaining", "599")
+			w.Header().Set("X-Rate-Limit-Reset", "60")
+			w.Write([]byte(`{
+				"group_msgid": "g-1",
+				"app-1": {"sendno": "0", "msg_id": "m-1"},
+				"app-2": {"error": {"code": 1011, "message": "cannot find user by this audience"}}
+			}`))
+		}))
+		defer srv.Close()
+
+		cli := NewGroupClient("g-key", "g-secret", SetHost(srv.URL))
+		payload := &Payload{
+			Platform:     NewPlatform().All(),
+			Audience:     NewAudience().All(),
+			Notification: &Notification{Alert: "hello"},
+		}
+
+		result, err := cli.GroupPush(context.Background(), payload)
+		So(err, ShouldBeNil)
+		So(path, ShouldEqual, "/v3/grouppush")
+		So(user, ShouldEqual, "group-g-key")
+		So(result.GroupMsgID, ShouldEqual, "g-1")
+		So(result.HeaderItem.XRateLimitRemaining, ShouldEqual, 599)
+
+		So(result.Results, ShouldHaveLength, 1)
+		So(result.Results["app-1"].MsgID, ShouldEqual, "m-1")
+		So(result.Results["app-1"].PushedAt.IsZero(), ShouldBeFalse)
+
+		So(result.Errors, ShouldHaveLength, 1)
+		So(result.Errors["app-2"].Code, ShouldEqual, 1011)
+
+		_, err = cli.GroupPushValidate(context.Background(), payload)
+		So(err, ShouldBeNil)
+		So(path, ShouldEqual, "/v3/grouppush/validate")
+	})
+}
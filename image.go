@@ -0,0 +1,182 @@
+package jpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+)
+
+var (
+	// ErrUnsupportedImageType 不支持的图片类型
+	ErrUnsupportedImageType = errors.New("unsupported image type")
+)
+
+// ImageType 图片类型
+type ImageType int
+
+// 定义图片类型
+const (
+	ImageLargeIcon  ImageType = 1 // 大图标
+	ImageBigPicture ImageType = 2 // 大图片
+)
+
+// ImageVendor 图片适配的通道
+type ImageVendor string
+
+// 定义图片适配的通道(ImageDefault 为极光通道及未单独指定图片的厂商通道)
+const (
+	ImageDefault ImageVendor = ""
+	ImageXiaomi  ImageVendor = "xiaomi"
+	ImageHuawei  ImageVendor = "huawei"
+	ImageHonor   ImageVendor = "honor"
+	ImageOPPO    ImageVendor = "oppo"
+	ImageFCM     ImageVendor = "fcm"
+)
+
+// field 通道对应的表单字段名
+func (v ImageVendor) field(name string) string {
+	if v == ImageDefault {
+		return name
+	}
+	return string(v) + "_" + name
+}
+
+// ImageFile 待上传的图片文件
+type ImageFile struct {
+	Name   string    // 文件名
+	Reader io.Reader // 文件内容
+}
+
+// NewImageClient 创建图片客户端实例
+func NewImageClient(opts ...Option) *ImageClient {
+	return newImageClient(newOptions(opts...))
+}
+
+func newImageClient(opts *options) *ImageClient {
+	return &ImageClient{
+		opts: opts,
+	}
+}
+
+// ImageClient 图片客户端(上传通知图片并获取 media_id)
+type ImageClient struct {
+	opts *options
+}
+
+// UploadURLs 通过图片地址上传图片
+func (c *ImageClient) UploadURLs(ctx context.Context, imageType ImageType, urls map[ImageVendor]string) (*ImageResult, error) {
+	return c.byURLs(ctx, "/v3/images/byurls", http.MethodPost, imageType, urls)
+}
+
+// UpdateURLs 通过图片地址更新图片
+func (c *ImageClient) UpdateURLs(ctx context.Context, mediaID string, urls map[ImageVendor]string) (*ImageResult, error) {
+	router := fmt.Sprintf("/v3/images/byurls/%s", url.PathEscape(mediaID))
+	return c.byURLs(ctx, router, http.MethodPut, 0, urls)
+}
+
+func (c *ImageClient) byURLs(ctx context.Context, router, method string, imageType ImageType, urls map[ImageVendor]string) (*ImageResult, error) {
+	v := make(map[string]interface{}, len(urls)+1)
+	if imageType != 0 {
+		v["image_type"] = imageType
+	}
+	for vendor, u := range urls {
+		v[vendor.field("image_url")] = u
+	}
+
+	buf, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := pushRequest(ctx, c.opts, router, method, bytes.NewReader(buf))
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ImageResult{ImageType: imageType}
+	err = resp.JSON(result)
+	if err != nil {
+		return nil, err
+	}
+	result.HeaderItem = newHeaderItem(resp.Response().Header)
+	return result, nil
+}
+
+// UploadFiles 通过文件上传图片(以流的方式写入请求)
+func (c *ImageClient) UploadFiles(ctx context.Context, imageType ImageType, files map[ImageVendor]*ImageFile) (*ImageResult, error) {
+	return c.byFiles(ctx, "/v3/images/byfiles", http.MethodPost, imageType, files)
+}
+
+// UpdateFiles 通过文件更新图片
+func (c *ImageClient) UpdateFiles(ctx context.Context, mediaID string, files map[ImageVendor]*ImageFile) (*ImageResult, error) {
+	router := fmt.Sprintf("/v3/images/byfiles/%s", url.PathEscape(mediaID))
+	return c.byFiles(ctx, router, http.MethodPut, 0, files)
+}
+
+func (c *ImageClient) byFiles(ctx context.Context, router, method string, imageType ImageType, files map[ImageVendor]*ImageFile) (*ImageResult, error) {
+	vendors := make([]string, 0, len(files))
+	for vendor := range files {
+		vendors = append(vendors, string(vendor))
+	}
+	sort.Strings(vendors)
+
+	result := &ImageResult{ImageType: imageType}
+	header, err := multipartRequest(ctx, c.opts, router, method, func(mw *multipart.Writer) error {
+		if imageType != 0 {
+			if err := mw.WriteField("image_type", strconv.Itoa(int(imageType))); err != nil {
+				return err
+			}
+		}
+
+		for _, vendor := range vendors {
+			file := files[ImageVendor(vendor)]
+			w, err := mw.CreateFormFile(ImageVendor(vendor).field("file"), file.Name)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(w, file.Reader); err != nil {
+				return err
+			}
+		}
+		return nil
+	}, result)
+	if err != nil {
+		return nil, err
+	}
+	result.HeaderItem = header
+	return result, nil
+}
+
+// ImageResult 图片上传结果
+type ImageResult struct {
+	MediaID        string      `json:"media_id"`
+	ImageURL       string      `json:"image_url,omitempty"`
+	XiaomiImageURL string      `json:"xiaomi_image_url,omitempty"`
+	HuaweiImageURL string      `json:"huawei_image_url,omitempty"`
+	HonorImageURL  string      `json:"honor_image_url,omitempty"`
+	OPPOImageURL   string      `json:"oppo_image_url,omitempty"`
+	FCMImageURL    string      `json:"fcm_image_url,omitempty"`
+	ImageType      ImageType   `json:"-"` // 上传时的图片类型(更新时为 0)
+	HeaderItem     *HeaderItem `json:"-"`
+}
+
+// FillAndroid 按图片类型将 media_id 填入 Android 通知
+func (r *ImageResult) FillAndroid(n *AndroidNotification) error {
+	switch r.ImageType {
+	case ImageLargeIcon:
+		n.SetLargeIcon(r.MediaID)
+	case ImageBigPicture:
+		n.SetStyle(3).SetBigPicPath(r.MediaID)
+	default:
+		return fmt.Errorf("%w: %d", ErrUnsupportedImageType, r.ImageType)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package jpush
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestImageUpload(t *testing.T) {
+	Convey("test image upload", t, func() {
+		var (
+			path   string
+			method string
+			files  = make(map[string]string)
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			method = r.Method
+
+			if err := r.ParseMultipartForm(1 << 20); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			for field := range r.MultipartForm.File {
+				file, _, _ := r.FormFile(field)
+				buf, _ := io.ReadAll(file)
+				files[field] = string(buf)
+			}
+			files["image_type"] = r.FormValue("image_type")
+
+			w.Write([]byte(`{"media_id":"jgmedia-2-1","oppo_image_url":"oppo-1"}`))
+		}))
+		defer srv.Close()
+
+		cli := NewImageClient(SetHost(srv.URL), SetAppKey(appKey), SetMasterSecret(masterSecret))
+		result, err := cli.UploadFiles(context.Background(), ImageBigPicture, map[ImageVendor]*ImageFile{
+			ImageDefault: {Name: "a.png", Reader: strings.NewReader("a")},
+			ImageOPPO:    {Name: "b.png", Reader: strings.NewReader("b")},
+		})
+		So(err, ShouldBeNil)
+		So(method, ShouldEqual, http.MethodPost)
+		So(path, ShouldEqual, "/v3/images/byfiles")
+		So(files["file"], ShouldEqual, "a")
+		So(files["oppo_file"], ShouldEqual, "b")
+		So(files["image_type"], ShouldEqual, "2")
+		So(result.MediaID, ShouldEqual, "jgmedia-2-1")
+		So(result.OPPOImageURL, ShouldEqual, "oppo-1")
+
+		n := new(AndroidNotification)
+		So(result.FillAndroid(n), ShouldBeNil)
+		So(n.Style, ShouldEqual, 3)
+		So(n.BigPicPath, ShouldEqual, "jgmedia-2-1")
+
+		files = make(map[string]string)
+		result, err = cli.UpdateFiles(context.Background(), "jgmedia-2-1", map[ImageVendor]*ImageFile{
+			ImageDefault: {Name: "a.png", Reader: strings.NewReader("a")},
+			ImageXiaomi:  {Name: "b.png", Reader: strings.NewReader("b")},
+		})
+		So(err, ShouldBeNil)
+		So(method, ShouldEqual, http.MethodPut)
+		So(path, ShouldEqual, "/v3/images/byfiles/jgmedia-2-1")
+		So(files["file"], ShouldEqual, "a")
+		So(files["xiaomi_file"], ShouldEqual, "b")
+		So(files["image_type"], ShouldEqual, "")
+
+		err = result.FillAndroid(new(AndroidNotification))
+		So(errors.Is(err, ErrUnsupportedImageType), ShouldBeTrue)
+	})
+}
@@ -0,0 +1,213 @@
+package jpush
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// NewIOSAlert 创建 iOS 通知内容实例
+func NewIOSAlert() *IOSAlert {
+	return new(IOSAlert)
+}
+
+// IOSAlert iOS 通知内容(仅包含 Body 时序列化为字符串)
+type IOSAlert struct {
+	Title       string   `json:"title,omitempty"`
+	Subtitle    string   `json:"subtitle,omitempty"`
+	Body        string   `json:"body,omitempty"`
+	TitleLocKey string   `json:"title-loc-key,omitempty"`
+	LocKey      string   `json:"loc-key,omitempty"`
+	LocArgs     []string `json:"loc-args,omitempty"`
+	LaunchImage string   `json:"launch-image,omitempty"`
+}
+
+// SetTitle 通知标题
+func (a *IOSAlert) SetTitle(title string) *IOSAlert {
+	a.Title = title
+	return a
+}
+
+// SetSubtitle 通知副标题
+func (a *IOSAlert) SetSubtitle(subtitle string) *IOSAlert {
+	a.Subtitle = subtitle
+	return a
+}
+
+// SetBody 通知内容
+func (a *IOSAlert) SetBody(body string) *IOSAlert {
+	a.Body = body
+	return a
+}
+
+// SetTitleLocKey 本地化标题的键
+func (a *IOSAlert) SetTitleLocKey(titleLocKey string) *IOSAlert {
+	a.TitleLocKey = titleLocKey
+	return a
+}
+
+// SetLocKey 本地化内容的键及格式化参数
+func (a *IOSAlert) SetLocKey(locKey string, locArgs ...string) *IOSAlert {
+	a.LocKey = locKey
+	a.LocArgs = locArgs
+	return a
+}
+
+// SetLaunchImage 点击通知启动时显示的图片
+func (a *IOSAlert) SetLaunchImage(launchImage string) *IOSAlert {
+	a.LaunchImage = launchImage
+	return a
+}
+
+// Validate 校验通知内容
+func (a *IOSAlert) Validate() error {
+	if len(a.LocArgs) > 0 && a.LocKey == "" {
+		return fmt.Errorf("%w: loc-args requires loc-key", ErrInvalidNotification)
+	}
+	return nil
+}
+
+// bodyOnly 是否仅包含通知内容
+func (a *IOSAlert) bodyOnly() bool {
+	return a.Title == "" && a.Subtitle == "" && a.TitleLocKey == "" &&
+		a.LocKey == "" && len(a.LocArgs) == 0 && a.LaunchImage == ""
+}
+
+// MarshalJSON 实现 JSON 接口
+func (a *IOSAlert) MarshalJSON() ([]byte, error) {
+	if a.bodyOnly() {
+		return json.Marshal(a.Body)
+	}
+
+	type alert IOSAlert
+	return json.Marshal((*alert)(a))
+}
+
+// UnmarshalJSON 实现 JSON 接口
+func (a *IOSAlert) UnmarshalJSON(data []byte) error {
+	var body string
+	if err := json.Unmarshal(data, &body); err == nil {
+		*a = IOSAlert{Body: body}
+		return nil
+	}
+
+	type alert IOSAlert
+	return json.Unmarshal(data, (*alert)(a))
+}
+
+// NewIOSBadge 创建固定值的应用角标
+func NewIOSBadge(value int) *IOSBadge {
+	return &IOSBadge{Value: value}
+}
+
+// NewIOSBadgeIncrement 创建在原角标基础上增减的应用角标(如 1 表示 "+1")
+func NewIOSBadgeIncrement(delta int) *IOSBadge {
+	return &IOSBadge{Value: delta, Increment: true}
+}
+
+// IOSBadge iOS 应用角标(固定值序列化为数字，增减值序列化为 "+1"、"-1" 形式的字符串)
+type IOSBadge struct {
+	Value     int
+	Increment bool
+}
+
+// Validate 校验应用角标
+func (b *IOSBadge) Validate() error {
+	if b.Increment {
+		if b.Value == 0 {
+			return fmt.Errorf("%w: badge increment must not be 0", ErrInvalidNotification)
+		}
+		return nil
+	}
+
+	if b.Value < 0 {
+		return fmt.Errorf("%w: badge must not be negative", ErrInvalidNotification)
+	}
+	return nil
+}
+
+// MarshalJSON 实现 JSON 接口
+func (b *IOSBadge) MarshalJSON() ([]byte, error) {
+	if b.Increment {
+		return json.Marshal(fmt.Sprintf("%+d", b.Value))
+	}
+	return json.Marshal(b.Value)
+}
+
+// UnmarshalJSON 实现 JSON 接口
+func (b *IOSBadge) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		b.Increment = false
+		return json.Unmarshal(data, &b.Value)
+	}
+
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return fmt.Errorf("invalid badge %q", s)
+	}
+	b.Value = v
+	b.Increment = strings.HasPrefix(s, "+") || strings.HasPrefix(s, "-")
+	return nil
+}
+
+// InterruptionLevel iOS 通知的中断级别
+type InterruptionLevel string
+
+func (l InterruptionLevel) String() string {
+	return string(l)
+}
+
+// 定义 iOS 通知的中断级别
+const (
+	InterruptionPassive       InterruptionLevel = "passive"        // 静默展示，不点亮屏幕
+	InterruptionActive        InterruptionLevel = "active"         // 默认级别
+	InterruptionTimeSensitive InterruptionLevel = "time-sensitive" // 可突破专注模式
+	InterruptionCritical      InterruptionLevel = "critical"       // 可突破静音及专注模式(需申请权限)
+)
+
+// IOSSound iOS 通知提示声音(非重要警告时序列化为字符串)
+type IOSSound struct {
+	Critical bool    // 是否为重要警告
+	Name     string  // 声音文件名
+	Volume   float64 // 重要警告的音量(0~1)
+}
+
+// Validate 校验提示声音
+func (s *IOSSound) Validate() error {
+	if s.Volume < 0 || s.Volume > 1 {
+		return fmt.Errorf("%w: sound volume must be between 0 and 1", ErrInvalidNotification)
+	}
+	return nil
+}
+
+type iosSound struct {
+	Critical int     `json:"critical"`
+	Name     string  `json:"name,omitempty"`
+	Volume   float64 `json:"volume"`
+}
+
+// MarshalJSON 实现 JSON 接口
+func (s *IOSSound) MarshalJSON() ([]byte, error) {
+	if !s.Critical {
+		return json.Marshal(s.Name)
+	}
+	return json.Marshal(&iosSound{Critical: 1, Name: s.Name, Volume: s.Volume})
+}
+
+// UnmarshalJSON 实现 JSON 接口
+func (s *IOSSound) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err == nil {
+		*s = IOSSound{Name: name}
+		return nil
+	}
+
+	var v iosSound
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*s = IOSSound{Critical: v.Critical != 0, Name: v.Name, Volume: v.Volume}
+	return nil
+}
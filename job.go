@@ -3,60 +3,172 @@ package jpush
 import (
 	"context"
 	"net/http"
-	"strings"
 	"time"
 
 	"github.com/LyricTian/queue"
 )
 
-func newPushJob(opts *options, queue queue.Queuer, cidClient *CIDClient) *pushJob {
+const pushEndpoint = "/v3/push"
+
+// pendingJob 已放入推送队列、尚未完成的任务
+type pendingJob interface {
+	queue.Jober
+	// pendingPayloads 任务中尚未完成的推送
+	pendingPayloads() []*Payload
+}
+
+// queuedJob 推送任务共用的频率限制及重新入队逻辑
+type queuedJob struct {
+	opts     *options
+	enqueue  func(queue.Jober) bool
+	reserved bool
+}
+
+// reserve 返回请求 endpoint 前需要等待的时长，已预留发送时间的任务仅在频次超出限制时继续等待
+func (q *queuedJob) reserve(endpoint string) time.Duration {
+	if q.reserved {
+		q.reserved = false
+		return q.opts.limiter.Paused(endpoint)
+	}
+
+	d := q.opts.limiter.Reserve(endpoint)
+	q.reserved = d > 0
+	return d
+}
+
+// schedule 等待 delay 后将任务重新放入队列，不占用工作协程，客户端已终止时调用 abort
+func (q *queuedJob) schedule(job queue.Jober, delay time.Duration, abort func()) {
+	time.AfterFunc(delay, func() {
+		if !q.enqueue(job) {
+			abort()
+		}
+	})
+}
+
+func newPushJob(opts *options, enqueue func(queue.Jober) bool, cidClient *CIDClient, wal *pushWAL, done func(pendingJob)) *pushJob {
 	return &pushJob{
-		opts:      opts,
-		queue:     queue,
+		queuedJob: queuedJob{opts: opts, enqueue: enqueue},
 		cidClient: cidClient,
+		wal:       wal,
+		done:      done,
 	}
 }
 
 type pushJob struct {
-	opts      *options
-	queue     queue.Queuer
+	queuedJob
 	cidClient *CIDClient
+	wal       *pushWAL
+	walID     uint64
+	done      func(pendingJob)
 	payload   *Payload
 	ctx       context.Context
 	callback  PushResultHandle
+	attempts  int
+	history   []*PushAttempt
 }
 
 func (j *pushJob) Reset(ctx context.Context, payload *Payload, callback PushResultHandle) {
 	j.payload = payload
 	j.ctx = ctx
 	j.callback = callback
+	j.walID = 0
+	j.attempts = 0
+	j.history = nil
+	j.reserved = false
+}
+
+// router 推送接口(按文件推送时为 /v3/push/file)
+func (j *pushJob) router() string {
+	if j.payload.Audience != nil && j.payload.Audience.File != nil {
+		return "/v3/push/file"
+	}
+	return pushEndpoint
+}
+
+func (j *pushJob) pendingPayloads() []*Payload {
+	return []*Payload{j.payload}
+}
+
+// requeue 等待 delay 后将任务重新放入队列
+func (j *pushJob) requeue(delay time.Duration) {
+	j.schedule(j, delay, j.abort)
+}
+
+// abort 客户端已终止，以 ErrClientClosed 结束任务(保留持久化队列中的记录，下次启动时重放)
+func (j *pushJob) abort() {
+	j.callback(withAttempts(j.ctx, j.attempts), nil, ErrClientClosed)
+
+	if j.done != nil {
+		j.done(j)
+	}
+}
+
+func (j *pushJob) finish(result *PushResult, err error) {
+	if j.walID != 0 {
+		j.wal.Ack(j.walID)
+	}
+	j.callback(withAttempts(j.ctx, j.attempts), result, err)
+
+	if j.done != nil {
+		j.done(j)
+	}
+}
+
+// deadLetter 将推送失败的任务写入接收器
+func (j *pushJob) deadLetter() {
+	sink := j.opts.deadLetterSink
+	if sink == nil {
+		return
+	}
+
+	// 推送因 ctx 结束而失败时仍需写入接收器
+	ctx := j.ctx
+	if ctx.Err() != nil {
+		ctx = context.Background()
+	}
+
+	sink.Put(ctx, &DeadLetter{
+		Payload:   j.payload,
+		CID:       j.payload.CID,
+		Attempts:  j.history,
+		CreatedAt: time.Now(),
+	})
+}
+
+// fail 推送最终失败，写入死信接收器后结束任务
+func (j *pushJob) fail(err error) {
+	j.deadLetter()
+	j.finish(nil, err)
 }
 
 func (j *pushJob) handleError(err error) {
 	if err == nil {
 		return
 	}
+	j.history = append(j.history, newPushAttempt(err))
 
-	if e, ok := err.(*Error); ok {
-		if e.StatusCode == 429 || e.StatusCode == 404 {
-			j.queue.Push(j)
-			// 如果当前推送频次超出限制，则将任务重新放入队列，并休眠等待
-			if e.HeaderItem != nil && e.HeaderItem.XRateLimitReset > 0 {
-				time.Sleep(time.Second * time.Duration(e.HeaderItem.XRateLimitReset))
-			}
-			return
-		}
-		j.callback(j.ctx, nil, err)
-	} else {
-		if v := err.Error(); strings.Contains(v, "connection refused") {
-			j.queue.Push(j)
-			return
-		}
-		j.callback(j.ctx, nil, err)
+	if ctxErr := j.ctx.Err(); ctxErr != nil {
+		j.fail(ctxErr)
+		return
+	}
+
+	policy := j.opts.retryPolicy
+	if policy == nil || !policy.Retryable(err) || policy.Exhausted(j.attempts) {
+		j.fail(err)
+		return
 	}
+
+	// 等待退避时长后重新放入队列，频次超出限制时由限制器暂停至重置时间
+	j.requeue(policy.Backoff(j.attempts))
 }
 
 func (j *pushJob) Job() {
+	if d := j.reserve(j.router()); d > 0 {
+		j.requeue(d)
+		return
+	}
+	j.attempts++
+
 	if j.payload.CID == "" {
 		cid, err := j.cidClient.GetPushID(j.ctx)
 		if err != nil {
@@ -64,9 +176,13 @@ func (j *pushJob) Job() {
 			return
 		}
 		j.payload.CID = cid
+
+		if j.walID != 0 {
+			j.wal.SetCID(j.walID, cid)
+		}
 	}
 
-	resp, err := pushRequest(j.ctx, j.opts, "/v3/push", http.MethodPost, j.payload.Reader())
+	resp, err := pushRequest(j.ctx, j.opts, j.router(), http.MethodPost, j.payload.Reader())
 	if err != nil {
 		j.handleError(err)
 		return
@@ -75,9 +191,12 @@ func (j *pushJob) Job() {
 	result := new(PushResult)
 	err = resp.JSON(result)
 	if err != nil {
-		j.callback(j.ctx, nil, err)
+		j.history = append(j.history, newPushAttempt(err))
+		j.fail(err)
 		return
 	}
+	result.HeaderItem = newHeaderItem(resp.Response().Header)
+	result.PushedAt = time.Now()
 
-	j.callback(j.ctx, result, nil)
+	j.finish(result, nil)
 }
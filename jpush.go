@@ -30,6 +30,11 @@ func Terminate() {
 	client().Terminate()
 }
 
+// Shutdown 优雅关闭客户端
+func Shutdown(ctx context.Context) error {
+	return client().Shutdown(ctx)
+}
+
 // GetPushID 获取推送ID
 func GetPushID(ctx context.Context) (string, error) {
 	return client().GetPushID(ctx)
@@ -45,7 +50,67 @@ func Push(ctx context.Context, payload *Payload, callback PushResultHandle) erro
 	return client().Push(ctx, payload, callback)
 }
 
+// PushAsync 异步推送，返回推送结果句柄
+func PushAsync(ctx context.Context, payload *Payload) *PushFuture {
+	return client().PushAsync(ctx, payload)
+}
+
+// PushSync 同步推送，阻塞等待推送结果
+func PushSync(ctx context.Context, payload *Payload) (*PushResult, error) {
+	return client().PushSync(ctx, payload)
+}
+
+// Quota 获取各接口最近一次响应的频率限制信息
+func Quota() map[string]*QuotaItem {
+	return client().Quota()
+}
+
+// RequeueDeadLetters 将推送失败的任务重新放入推送队列
+func RequeueDeadLetters(ctx context.Context, letters []*DeadLetter, callback PushResultHandle) error {
+	return client().RequeueDeadLetters(ctx, letters, callback)
+}
+
+// Withdraw 撤回推送消息
+func Withdraw(ctx context.Context, msgID string) error {
+	return client().Withdraw(ctx, msgID)
+}
+
+// WithdrawResult 按推送结果撤回推送消息
+func WithdrawResult(ctx context.Context, result *PushResult) error {
+	return client().WithdrawResult(ctx, result)
+}
+
+// BatchSinglePush 批量单推
+func BatchSinglePush(ctx context.Context, target BatchTarget, payloads map[string]*Payload) (map[string]*BatchPushResult, error) {
+	return client().BatchSinglePush(ctx, target, payloads)
+}
+
 // PushValidate 先校验，再推送
 func PushValidate(ctx context.Context, payload *Payload, callback PushResultHandle) error {
 	return client().PushValidate(ctx, payload, callback)
 }
+
+// CreateSchedule 创建定时任务
+func CreateSchedule(ctx context.Context, schedule *Schedule) (*ScheduleResult, error) {
+	return client().CreateSchedule(ctx, schedule)
+}
+
+// ListSchedules 获取有效的定时任务列表
+func ListSchedules(ctx context.Context, page int) (*ScheduleList, error) {
+	return client().ListSchedules(ctx, page)
+}
+
+// GetSchedule 获取定时任务详情
+func GetSchedule(ctx context.Context, scheduleID string) (*Schedule, error) {
+	return client().GetSchedule(ctx, scheduleID)
+}
+
+// UpdateSchedule 更新定时任务
+func UpdateSchedule(ctx context.Context, scheduleID string, schedule *Schedule) (*Schedule, error) {
+	return client().UpdateSchedule(ctx, scheduleID, schedule)
+}
+
+// DeleteSchedule 删除定时任务
+func DeleteSchedule(ctx context.Context, scheduleID string) error {
+	return client().DeleteSchedule(ctx, scheduleID)
+}
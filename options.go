@@ -1,8 +1,11 @@
 package jpush
 
 var defaultOptions = options{
-	host:     "https://api.jpush.cn",
-	cidCount: 1000,
+	host:        "https://api.jpush.cn",
+	deviceHost:  "https://device.jpush.cn",
+	reportHost:  "https://report.jpush.cn",
+	cidCount:    1000,
+	retryPolicy: NewRetryPolicy(),
 }
 
 // Option 配置项
@@ -15,6 +18,20 @@ func SetHost(host string) Option {
 	}
 }
 
+// SetDeviceHost 设定设备、标签及别名接口的请求地址
+func SetDeviceHost(host string) Option {
+	return func(o *options) {
+		o.deviceHost = host
+	}
+}
+
+// SetReportHost 设定统计接口的请求地址
+func SetReportHost(host string) Option {
+	return func(o *options) {
+		o.reportHost = host
+	}
+}
+
 // SetAppKey 设定 Appkey
 func SetAppKey(appKey string) Option {
 	return func(o *options) {
@@ -36,9 +53,54 @@ func SetCIDCount(count int) Option {
 	}
 }
 
+// SetRetryPolicy 设定推送失败时的重试策略
+func SetRetryPolicy(policy *RetryPolicy) Option {
+	return func(o *options) {
+		o.retryPolicy = policy
+	}
+}
+
+func newOptions(opts ...Option) *options {
+	o := defaultOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	o.limiter = newRateLimiter()
+	return &o
+}
+
+// SetDeadLetterSink 设定推送失败任务的接收器
+func SetDeadLetterSink(sink DeadLetterSink) Option {
+	return func(o *options) {
+		o.deadLetterSink = sink
+	}
+}
+
+// SetQueueDir 设定持久化推送队列的目录，客户端启动时将重放尚未完成的推送
+func SetQueueDir(dir string) Option {
+	return func(o *options) {
+		o.queueDir = dir
+	}
+}
+
+// SetReplayHandle 设定重放推送的异步响应结果
+func SetReplayHandle(callback PushResultHandle) Option {
+	return func(o *options) {
+		o.replayHandle = callback
+	}
+}
+
 type options struct {
 	host         string
+	deviceHost   string
+	reportHost   string
 	appKey       string
 	masterSecret string
 	cidCount     int
+	retryPolicy  *RetryPolicy
+	limiter      *rateLimiter
+
+	deadLetterSink DeadLetterSink
+	queueDir       string
+	replayHandle   PushResultHandle
 }
@@ -3,7 +3,16 @@ package jpush
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"net/url"
+	"time"
+)
+
+var (
+	// ErrInvalidNotification 无效的通知
+	ErrInvalidNotification = errors.New("invalid notification")
 )
 
 // OS 推送平台
@@ -44,6 +53,19 @@ func (p *Payload) Reader() io.Reader {
 	return buf
 }
 
+// Validate 校验推送载荷(不发起网络请求)
+func (p *Payload) Validate() error {
+	if p.Notification != nil {
+		if err := p.Notification.Validate(); err != nil {
+			return err
+		}
+	}
+	if p.Options != nil {
+		return p.Options.Validate()
+	}
+	return nil
+}
+
 // NewPlatform 创建推送平台实例
 func NewPlatform() *Platform {
 	return new(Platform)
@@ -63,6 +85,16 @@ func (p *Platform) MarshalJSON() ([]byte, error) {
 	return json.Marshal(p.Value)
 }
 
+// UnmarshalJSON 实现 JSON 接口
+func (p *Platform) UnmarshalJSON(data []byte) error {
+	var all string
+	if err := json.Unmarshal(data, &all); err == nil {
+		p.IsAll = all == "all"
+		return nil
+	}
+	return json.Unmarshal(data, &p.Value)
+}
+
 // All 推送到所有平台
 func (p *Platform) All() *Platform {
 	p.IsAll = true
@@ -97,14 +129,59 @@ func NewAudience() *Audience {
 type Audience struct {
 	IsAll bool
 	Value map[string][]string
+	File  *AudienceFile
+}
+
+// AudienceFile 文件推送目标
+type AudienceFile struct {
+	FileID string `json:"file_id"`
 }
 
 // MarshalJSON 实现 JSON 接口
 func (a *Audience) MarshalJSON() ([]byte, error) {
 	if a.IsAll {
 		return json.Marshal("all")
+	} else if a.File == nil {
+		return json.Marshal(a.Value)
+	}
+
+	v := make(map[string]interface{}, len(a.Value)+1)
+	for key, values := range a.Value {
+		v[key] = values
+	}
+	v["file"] = a.File
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON 实现 JSON 接口
+func (a *Audience) UnmarshalJSON(data []byte) error {
+	var all string
+	if err := json.Unmarshal(data, &all); err == nil {
+		a.IsAll = all == "all"
+		return nil
+	}
+
+	var v map[string]json.RawMessage
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	for key, raw := range v {
+		if key == "file" {
+			a.File = new(AudienceFile)
+			if err := json.Unmarshal(raw, a.File); err != nil {
+				return err
+			}
+			continue
+		}
+
+		var values []string
+		if err := json.Unmarshal(raw, &values); err != nil {
+			return err
+		}
+		a.SetValue(key, values...)
 	}
-	return json.Marshal(a.Value)
+	return nil
 }
 
 // All 全部设备
@@ -153,6 +230,12 @@ func (a *Audience) SetSegment(segments ...string) *Audience {
 	return a.SetValue("segment", segments...)
 }
 
+// SetFile 设定文件推送目标(文件需先通过 FileClient 上传，推送时使用 /v3/push/file 接口)
+func (a *Audience) SetFile(fileID string) *Audience {
+	a.File = &AudienceFile{FileID: fileID}
+	return a
+}
+
 // SetAbTest 设定A/B Test ID
 func (a *Audience) SetAbTest(abtests ...string) *Audience {
 	return a.SetValue("abtest", abtests...)
@@ -189,6 +272,19 @@ func (n *Notification) SetIOSNotification(ios *IOSNotification) *Notification {
 	return n
 }
 
+// Validate 校验通知
+func (n *Notification) Validate() error {
+	if n.Android != nil {
+		if err := n.Android.Validate(); err != nil {
+			return err
+		}
+	}
+	if n.IOS != nil {
+		return n.IOS.Validate()
+	}
+	return nil
+}
+
 // SetWinPhoneNotification 设定 Windows Phone 平台上的通知
 func (n *Notification) SetWinPhoneNotification(winPhone *WinPhoneNotification) *Notification {
 	n.WinPhone = winPhone
@@ -213,6 +309,24 @@ type AndroidNotification struct {
 	Inbox      map[string]interface{} `json:"inbox,omitempty"`
 	BigPicPath string                 `json:"big_pic_path,omitempty"`
 	Extras     map[string]interface{} `json:"extras,omitempty"`
+
+	ChannelID         string         `json:"channel_id,omitempty"`
+	Intent            *AndroidIntent `json:"intent,omitempty"`
+	URIActivity       string         `json:"uri_activity,omitempty"`
+	URIAction         string         `json:"uri_action,omitempty"`
+	BadgeAddNum       int            `json:"badge_add_num,omitempty"`
+	BadgeClass        string         `json:"badge_class,omitempty"`
+	Sound             string         `json:"sound,omitempty"`
+	SmallIconURI      string         `json:"small_icon_uri,omitempty"`
+	LargeIcon         string         `json:"large_icon,omitempty"`
+	DisplayForeground string         `json:"display_foreground,omitempty"`
+	ShowBeginTime     string         `json:"show_begin_time,omitempty"`
+	ShowEndTime       string         `json:"show_end_time,omitempty"`
+}
+
+// AndroidIntent 通知点击跳转的目标页面
+type AndroidIntent struct {
+	URL string `json:"url"`
 }
 
 // SetAlert 通知内容
@@ -281,6 +395,117 @@ func (n *AndroidNotification) SetExtras(extras map[string]interface{}) *AndroidN
 	return n
 }
 
+// SetChannelID 通知渠道 ID
+func (n *AndroidNotification) SetChannelID(channelID string) *AndroidNotification {
+	n.ChannelID = channelID
+	return n
+}
+
+// SetIntent 通知点击跳转的目标页面(如 intent:#Intent;component=包名/Activity 全名;end)
+func (n *AndroidNotification) SetIntent(intentURL string) *AndroidNotification {
+	n.Intent = &AndroidIntent{URL: intentURL}
+	return n
+}
+
+// SetURIActivity 厂商通道通知点击跳转的 Activity
+func (n *AndroidNotification) SetURIActivity(uriActivity string) *AndroidNotification {
+	n.URIActivity = uriActivity
+	return n
+}
+
+// SetURIAction 厂商通道通知点击跳转的 Action
+func (n *AndroidNotification) SetURIAction(uriAction string) *AndroidNotification {
+	n.URIAction = uriAction
+	return n
+}
+
+// SetBadge 角标累加数(1~99)及桌面图标对应的应用入口 Activity
+func (n *AndroidNotification) SetBadge(addNum int, class string) *AndroidNotification {
+	n.BadgeAddNum = addNum
+	n.BadgeClass = class
+	return n
+}
+
+// SetSound 通知铃声(res/raw 下的文件名，不含后缀)
+func (n *AndroidNotification) SetSound(sound string) *AndroidNotification {
+	n.Sound = sound
+	return n
+}
+
+// SetSmallIconURI 通知栏小图标(资源名、网络地址或 media_id)
+func (n *AndroidNotification) SetSmallIconURI(smallIconURI string) *AndroidNotification {
+	n.SmallIconURI = smallIconURI
+	return n
+}
+
+// SetLargeIcon 通知栏大图标(资源名、网络地址或 media_id)
+func (n *AndroidNotification) SetLargeIcon(largeIcon string) *AndroidNotification {
+	n.LargeIcon = largeIcon
+	return n
+}
+
+// SetDisplayForeground 应用在前台时是否展示通知
+func (n *AndroidNotification) SetDisplayForeground(display bool) *AndroidNotification {
+	n.DisplayForeground = "0"
+	if display {
+		n.DisplayForeground = "1"
+	}
+	return n
+}
+
+// SetShowTime 通知的展示时间段(转换为服务端时区，零值表示不限制)
+func (n *AndroidNotification) SetShowTime(begin, end time.Time) *AndroidNotification {
+	n.ShowBeginTime, n.ShowEndTime = "", ""
+	if !begin.IsZero() {
+		n.ShowBeginTime = begin.In(serverLocation).Format(serverTimeLayout)
+	}
+	if !end.IsZero() {
+		n.ShowEndTime = end.In(serverLocation).Format(serverTimeLayout)
+	}
+	return n
+}
+
+// Validate 校验 Android 通知
+func (n *AndroidNotification) Validate() error {
+	if n.Intent != nil {
+		u, err := url.Parse(n.Intent.URL)
+		if err != nil || u.Scheme == "" {
+			return fmt.Errorf("%w: intent url %q is malformed", ErrInvalidNotification, n.Intent.URL)
+		}
+	}
+
+	if n.BadgeAddNum < 0 || n.BadgeAddNum > 99 {
+		return fmt.Errorf("%w: badge_add_num must be between 1 and 99", ErrInvalidNotification)
+	}
+
+	switch n.DisplayForeground {
+	case "", "0", "1":
+	default:
+		return fmt.Errorf("%w: display_foreground must be 0 or 1", ErrInvalidNotification)
+	}
+
+	var begin, end time.Time
+	if n.ShowBeginTime != "" {
+		t, err := time.ParseInLocation(serverTimeLayout, n.ShowBeginTime, serverLocation)
+		if err != nil {
+			return fmt.Errorf("%w: show_begin_time %q is not in yyyy-MM-dd HH:mm:ss format", ErrInvalidNotification, n.ShowBeginTime)
+		}
+		begin = t
+	}
+	if n.ShowEndTime != "" {
+		t, err := time.ParseInLocation(serverTimeLayout, n.ShowEndTime, serverLocation)
+		if err != nil {
+			return fmt.Errorf("%w: show_end_time %q is not in yyyy-MM-dd HH:mm:ss format", ErrInvalidNotification, n.ShowEndTime)
+		}
+		end = t
+	}
+	if !begin.IsZero() && !end.IsZero() && !end.After(begin) {
+		return fmt.Errorf("%w: show_end_time must be after show_begin_time", ErrInvalidNotification)
+	}
+
+	return nil
+}
+
 // NewIOSNotification 创建 iOS 平台上的通知实例
 func NewIOSNotification() *IOSNotification {
 	return new(IOSNotification)
@@ -288,30 +513,52 @@ func NewIOSNotification() *IOSNotification {
 
 // IOSNotification iOS 平台上 APNs 通知结构
 type IOSNotification struct {
-	Alert            interface{}            `json:"alert"`
-	Sound            string                 `json:"sound,omitempty"`
-	Badge            interface{}            `json:"badge,omitempty"`
-	ContentAvailable bool                   `json:"content-available,omitempty"`
-	MutableContent   bool                   `json:"mutable-content,omitempty"`
-	Category         string                 `json:"category,omitempty"`
-	Extras           map[string]interface{} `json:"extras,omitempty"`
+	Alert             *IOSAlert              `json:"alert"`
+	Sound             *IOSSound              `json:"sound,omitempty"`
+	Badge             *IOSBadge              `json:"badge,omitempty"`
+	ContentAvailable  bool                   `json:"content-available,omitempty"`
+	MutableContent    bool                   `json:"mutable-content,omitempty"`
+	Category          string                 `json:"category,omitempty"`
+	Extras            map[string]interface{} `json:"extras,omitempty"`
+	ThreadID          string                 `json:"thread-id,omitempty"`
+	InterruptionLevel InterruptionLevel      `json:"interruption-level,omitempty"`
+	RelevanceScore    *float64               `json:"relevance-score,omitempty"`
+	TargetContentID   string                 `json:"target-content-id,omitempty"`
 }
 
 // SetAlert 通知内容
-func (n *IOSNotification) SetAlert(alert interface{}) *IOSNotification {
+func (n *IOSNotification) SetAlert(alert string) *IOSNotification {
+	n.Alert = &IOSAlert{Body: alert}
+	return n
+}
+
+// SetIOSAlert 通知内容(包含标题、副标题及本地化参数)
+func (n *IOSNotification) SetIOSAlert(alert *IOSAlert) *IOSNotification {
 	n.Alert = alert
 	return n
 }
 
 // SetSound 通知提示声音
 func (n *IOSNotification) SetSound(sound string) *IOSNotification {
-	n.Sound = sound
+	n.Sound = &IOSSound{Name: sound}
+	return n
+}
+
+// SetCriticalSound 重要警告的提示声音及音量(0~1)
+func (n *IOSNotification) SetCriticalSound(sound string, volume float64) *IOSNotification {
+	n.Sound = &IOSSound{Critical: true, Name: sound, Volume: volume}
+	return n
+}
+
+// SetBadge 应用角标(固定值)
+func (n *IOSNotification) SetBadge(badge int) *IOSNotification {
+	n.Badge = NewIOSBadge(badge)
 	return n
 }
 
-// SetBadge 应用角标
-func (n *IOSNotification) SetBadge(badge interface{}) *IOSNotification {
-	n.Badge = badge
+// SetBadgeIncrement 应用角标(在原角标基础上增减)
+func (n *IOSNotification) SetBadgeIncrement(delta int) *IOSNotification {
+	n.Badge = NewIOSBadgeIncrement(delta)
 	return n
 }
 
@@ -339,6 +586,58 @@ func (n *IOSNotification) SetExtras(extras map[string]interface{}) *IOSNotificat
 	return n
 }
 
+// SetThreadID 通知分组 ID
+func (n *IOSNotification) SetThreadID(threadID string) *IOSNotification {
+	n.ThreadID = threadID
+	return n
+}
+
+// SetInterruptionLevel 通知的中断级别(iOS 15+)
+func (n *IOSNotification) SetInterruptionLevel(level InterruptionLevel) *IOSNotification {
+	n.InterruptionLevel = level
+	return n
+}
+
+// SetRelevanceScore 通知摘要中的排序权重(0~1，iOS 15+)
+func (n *IOSNotification) SetRelevanceScore(score float64) *IOSNotification {
+	n.RelevanceScore = &score
+	return n
+}
+
+// SetTargetContentID 点击通知时要打开的窗口 ID
+func (n *IOSNotification) SetTargetContentID(targetContentID string) *IOSNotification {
+	n.TargetContentID = targetContentID
+	return n
+}
+
+// Validate 校验 iOS 通知
+func (n *IOSNotification) Validate() error {
+	switch n.InterruptionLevel {
+	case "", InterruptionPassive, InterruptionActive, InterruptionTimeSensitive, InterruptionCritical:
+	default:
+		return fmt.Errorf("%w: unknown interruption-level %q", ErrInvalidNotification, n.InterruptionLevel)
+	}
+
+	if n.RelevanceScore != nil && (*n.RelevanceScore < 0 || *n.RelevanceScore > 1) {
+		return fmt.Errorf("%w: relevance-score must be between 0 and 1", ErrInvalidNotification)
+	}
+
+	if n.Sound != nil {
+		if err := n.Sound.Validate(); err != nil {
+			return err
+		}
+	}
+	if n.Alert != nil {
+		if err := n.Alert.Validate(); err != nil {
+			return err
+		}
+	}
+	if n.Badge != nil {
+		return n.Badge.Validate()
+	}
+	return nil
+}
+
 // NewWinPhoneNotification 创建 Windows Phone 平台上的通知实例
 func NewWinPhoneNotification() *WinPhoneNotification {
 	return new(WinPhoneNotification)
@@ -456,6 +755,8 @@ type Options struct {
 	ApnsProduction  bool   `json:"apns_production"`
 	ApnsCollapseID  string `json:"apns_collapse_id,omitempty"`
 	BigPushDuration int    `json:"big_push_duration,omitempty"`
+
+	ThirdPartyChannel *ThirdPartyChannel `json:"third_party_channel,omitempty"`
 }
 
 // SetSendNO 推送序号
@@ -493,3 +794,17 @@ func (o *Options) SetBigPushDuration(bigPushDuration int) *Options {
 	o.BigPushDuration = bigPushDuration
 	return o
 }
+
+// SetThirdPartyChannel 厂商通道参数
+func (o *Options) SetThirdPartyChannel(channel *ThirdPartyChannel) *Options {
+	o.ThirdPartyChannel = channel
+	return o
+}
+
+// Validate 校验可选参数
+func (o *Options) Validate() error {
+	if o.ThirdPartyChannel != nil {
+		return o.ThirdPartyChannel.Validate()
+	}
+	return nil
+}
@@ -0,0 +1,134 @@
+package jpush
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestAndroidNotification(t *testing.T) {
+	Convey("test android notification", t, func() {
+		begin := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		n := NewAndroidNotification().
+			SetAlert("hello").
+			SetChannelID("c1").
+			SetIntent("intent:#Intent;component=com.example/.MainActivity;end").
+			SetBadge(1, "com.example.MainActivity").
+			SetDisplayForeground(false).
+			SetShowTime(begin, begin.Add(time.Hour))
+		So(n.Validate(), ShouldBeNil)
+		So(n.ShowBeginTime, ShouldEqual, "2024-01-01 08:00:00")
+		So(n.ShowEndTime, ShouldEqual, "2024-01-01 09:00:00")
+
+		buf, err := json.Marshal(n)
+		So(err, ShouldBeNil)
+		So(string(buf), ShouldContainSubstring, `"intent":{"url":"intent:#Intent;component=com.example/.MainActivity;end"}`)
+		So(string(buf), ShouldContainSubstring, `"display_foreground":"0"`)
+
+		n.SetShowTime(begin, begin.Add(-time.Hour))
+		So(errors.Is(n.Validate(), ErrInvalidNotification), ShouldBeTrue)
+
+		n.SetShowTime(time.Time{}, time.Time{}).SetIntent("com.example/.MainActivity")
+		So(errors.Is(n.Validate(), ErrInvalidNotification), ShouldBeTrue)
+
+		payload := &Payload{Notification: NewNotification().SetAndroidNotification(n)}
+		So(errors.Is(payload.Validate(), ErrInvalidNotification), ShouldBeTrue)
+	})
+}
+
+func TestThirdPartyChannel(t *testing.T) {
+	Convey("test third party channel", t, func() {
+		channel := NewThirdPartyChannel().
+			SetXiaomi(NewVendorChannel().SetDistribution(DistributionFirstOSPush).SetChannelID("c1")).
+			SetVivo(NewVendorChannel().SetDistribution(DistributionOSPush).SetClassification(1))
+		options := NewOptions().SetThirdPartyChannel(channel)
+		So(options.Validate(), ShouldBeNil)
+
+		buf, err := json.Marshal(options)
+		So(err, ShouldBeNil)
+		So(string(buf), ShouldContainSubstring, `"third_party_channel":{"xiaomi":{"distribution":"first_ospush","channel_id":"c1"},"vivo":{"distribution":"ospush","classification":1}}`)
+
+		channel.SetMeizu(NewVendorChannel().SetImportance("HIGH"))
+		err = (&Payload{Options: options}).Validate()
+		So(errors.Is(err, ErrInvalidOptions), ShouldBeTrue)
+		So(err.Error(), ShouldContainSubstring, "importance is not supported by meizu")
+
+		channel.SetMeizu(NewVendorChannel().SetDistribution("unknown"))
+		So(errors.Is(options.Validate(), ErrInvalidOptions), ShouldBeTrue)
+	})
+}
+
+func TestIOSNotification(t *testing.T) {
+	Convey("test ios notification", t, func() {
+		n := NewIOSNotification().SetAlert("hello").SetBadgeIncrement(1)
+		So(n.Validate(), ShouldBeNil)
+
+		buf, err := json.Marshal(n)
+		So(err, ShouldBeNil)
+		So(string(buf), ShouldEqual, `{"alert":"hello","badge":"+1"}`)
+
+		decoded := new(IOSNotification)
+		So(json.Unmarshal(buf, decoded), ShouldBeNil)
+		So(decoded.Alert.Body, ShouldEqual, "hello")
+		So(*decoded.Badge, ShouldResemble, IOSBadge{Value: 1, Increment: true})
+
+		n.SetIOSAlert(NewIOSAlert().SetTitle("t").SetBody("b").SetLocKey("k", "a1")).SetBadge(5)
+		buf, err = json.Marshal(n)
+		So(err, ShouldBeNil)
+		So(string(buf), ShouldEqual, `{"alert":{"title":"t","body":"b","loc-key":"k","loc-args":["a1"]},"badge":5}`)
+
+		decoded = new(IOSNotification)
+		So(json.Unmarshal(buf, decoded), ShouldBeNil)
+		So(decoded.Alert, ShouldResemble, n.Alert)
+		So(*decoded.Badge, ShouldResemble, IOSBadge{Value: 5})
+
+		n.Alert.LocKey = ""
+		So(errors.Is(n.Validate(), ErrInvalidNotification), ShouldBeTrue)
+
+		n.SetAlert("hello").SetBadge(-1)
+		So(errors.Is(NewNotification().SetIOSNotification(n).Validate(), ErrInvalidNotification), ShouldBeTrue)
+	})
+}
+
+func TestIOSInterruption(t *testing.T) {
+	Convey("test ios interruption level and critical sound", t, func() {
+		n := NewIOSNotification().
+			SetAlert("hello").
+			SetThreadID("t1").
+			SetInterruptionLevel(InterruptionCritical).
+			SetRelevanceScore(0.5).
+			SetCriticalSound("default", 1)
+		So(n.Validate(), ShouldBeNil)
+
+		buf, err := json.Marshal(n)
+		So(err, ShouldBeNil)
+		So(string(buf), ShouldEqual, `{"alert":"hello","sound":{"critical":1,"name":"default","volume":1},"thread-id":"t1","interruption-level":"critical","relevance-score":0.5}`)
+
+		decoded := new(IOSNotification)
+		So(json.Unmarshal(buf, decoded), ShouldBeNil)
+		So(decoded.Sound, ShouldResemble, n.Sound)
+
+		So(json.Unmarshal([]byte(`{"alert":"hello","sound":"ding"}`), decoded), ShouldBeNil)
+		So(*decoded.Sound, ShouldResemble, IOSSound{Name: "ding"})
+
+		n.SetRelevanceScore(1.5)
+		So(errors.Is(n.Validate(), ErrInvalidNotification), ShouldBeTrue)
+
+		// 排序权重为 0 时仍需发送
+		n.SetRelevanceScore(0).SetSound("default")
+		So(n.Validate(), ShouldBeNil)
+		buf, err = json.Marshal(n)
+		So(err, ShouldBeNil)
+		So(string(buf), ShouldContainSubstring, `"sound":"default"`)
+		So(string(buf), ShouldContainSubstring, `"relevance-score":0`)
+
+		n.SetCriticalSound("default", 2)
+		So(errors.Is(n.Validate(), ErrInvalidNotification), ShouldBeTrue)
+
+		n.SetSound("default").SetInterruptionLevel("urgent")
+		So(errors.Is(n.Validate(), ErrInvalidNotification), ShouldBeTrue)
+	})
+}
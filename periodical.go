@@ -0,0 +1,246 @@
+package jpush
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// TimeUnit 定期任务的时间单位
+type TimeUnit string
+
+func (u TimeUnit) String() string {
+	return string(u)
+}
+
+// 定义定期任务的时间单位
+const (
+	TimeUnitDay   TimeUnit = "day"
+	TimeUnitWeek  TimeUnit = "week"
+	TimeUnitMonth TimeUnit = "month"
+)
+
+// 每周的时间点(按 time.Weekday 的顺序)
+var weekPoints = []string{"SUN", "MON", "TUE", "WED", "THU", "FRI", "SAT"}
+
+// NewPeriodical 创建定期任务触发条件实例
+func NewPeriodical() *PeriodicalTrigger {
+	return &PeriodicalTrigger{
+		Frequency: 1,
+	}
+}
+
+// PeriodicalTrigger 定期任务触发条件
+type PeriodicalTrigger struct {
+	Start     time.Time      // 开始时间
+	End       time.Time      // 结束时间
+	Time      string         // 触发时间(HH:mm:ss)
+	TimeUnit  TimeUnit       // 时间单位
+	Frequency int            // 频率(1-100)
+	Point     []string       // 时间点(week: MON-SUN，month: 01-31)
+	Location  *time.Location // Time 和 Point 所在的时区，为空时使用服务端时区
+}
+
+type periodicalTrigger struct {
+	Start     string   `json:"start"`
+	End       string   `json:"end"`
+	Time      string   `json:"time"`
+	TimeUnit  TimeUnit `json:"time_unit"`
+	Frequency int      `json:"frequency"`
+	Point     []string `json:"point,omitempty"`
+}
+
+// SetStart 开始时间
+func (p *PeriodicalTrigger) SetStart(start time.Time) *PeriodicalTrigger {
+	p.Start = start
+	return p
+}
+
+// SetEnd 结束时间
+func (p *PeriodicalTrigger) SetEnd(end time.Time) *PeriodicalTrigger {
+	p.End = end
+	return p
+}
+
+// SetTime 触发时间(HH:mm:ss)
+func (p *PeriodicalTrigger) SetTime(clock string) *PeriodicalTrigger {
+	p.Time = clock
+	return p
+}
+
+// SetTimeUnit 时间单位
+func (p *PeriodicalTrigger) SetTimeUnit(timeUnit TimeUnit) *PeriodicalTrigger {
+	p.TimeUnit = timeUnit
+	return p
+}
+
+// SetFrequency 频率
+func (p *PeriodicalTrigger) SetFrequency(frequency int) *PeriodicalTrigger {
+	p.Frequency = frequency
+	return p
+}
+
+// SetPoint 时间点
+func (p *PeriodicalTrigger) SetPoint(points ...string) *PeriodicalTrigger {
+	p.Point = points
+	return p
+}
+
+// SetLocation 设定 Time 和 Point 所在的时区
+func (p *PeriodicalTrigger) SetLocation(loc *time.Location) *PeriodicalTrigger {
+	p.Location = loc
+	return p
+}
+
+// Validate 校验触发条件
+func (p *PeriodicalTrigger) Validate() error {
+	if p.Start.IsZero() || p.End.IsZero() {
+		return fmt.Errorf("%w: start and end are required", ErrInvalidTrigger)
+	} else if !p.End.After(p.Start) {
+		return fmt.Errorf("%w: end must be after start", ErrInvalidTrigger)
+	}
+
+	if _, err := time.Parse(scheduleClockLayout, p.Time); err != nil {
+		return fmt.Errorf("%w: time %q is not in HH:mm:ss format", ErrInvalidTrigger, p.Time)
+	}
+
+	if p.Frequency < 1 || p.Frequency > 100 {
+		return fmt.Errorf("%w: frequency must be between 1 and 100", ErrInvalidTrigger)
+	}
+
+	switch p.TimeUnit {
+	case TimeUnitDay:
+		if len(p.Point) > 0 {
+			return fmt.Errorf("%w: point is not supported by time unit %s", ErrInvalidTrigger, p.TimeUnit)
+		}
+	case TimeUnitWeek, TimeUnitMonth:
+		if len(p.Point) == 0 {
+			return fmt.Errorf("%w: point is required by time unit %s", ErrInvalidTrigger, p.TimeUnit)
+		}
+		_, shift := p.serverClock()
+		for _, point := range p.Point {
+			i, ok := pointIndex(p.TimeUnit, point)
+			if !ok {
+				return fmt.Errorf("%w: point %q does not fit time unit %s", ErrInvalidTrigger, point, p.TimeUnit)
+			}
+
+			// 跨月平移后的日期在部分月份中不存在(如 31 日、2 月 29 日)，服务端会跳过这些月份
+			if day := i + 1 + shift; p.TimeUnit == TimeUnitMonth && shift != 0 && (day < 1 || day > 28) {
+				return fmt.Errorf("%w: point %q crosses a month boundary in the server time zone", ErrInvalidTrigger, point)
+			}
+		}
+	default:
+		return fmt.Errorf("%w: unknown time unit %q", ErrInvalidTrigger, p.TimeUnit)
+	}
+
+	return nil
+}
+
+// serverClock 以开始日期为基准，将本地触发时间换算为服务端时间，返回服务端时间及跨越的天数
+func (p *PeriodicalTrigger) serverClock() (time.Time, int) {
+	loc := p.Location
+	if loc == nil {
+		loc = serverLocation
+	}
+
+	clock, _ := time.Parse(scheduleClockLayout, p.Time)
+	start := p.Start.In(loc)
+	local := time.Date(start.Year(), start.Month(), start.Day(), clock.Hour(), clock.Minute(), clock.Second(), 0, loc)
+	server := local.In(serverLocation)
+	return server, dayNumber(server) - dayNumber(local)
+}
+
+// MarshalJSON 实现 JSON 接口，将时间转换为服务端时区
+func (p *PeriodicalTrigger) MarshalJSON() ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	server, shift := p.serverClock()
+
+	points := make([]string, len(p.Point))
+	for i, point := range p.Point {
+		points[i] = shiftPoint(p.TimeUnit, point, shift)
+	}
+
+	return json.Marshal(&periodicalTrigger{
+		Start:     p.Start.In(serverLocation).Format(serverTimeLayout),
+		End:       p.End.In(serverLocation).Format(serverTimeLayout),
+		Time:      server.Format(scheduleClockLayout),
+		TimeUnit:  p.TimeUnit,
+		Frequency: p.Frequency,
+		Point:     points,
+	})
+}
+
+// UnmarshalJSON 实现 JSON 接口，解析后的时间位于服务端时区
+func (p *PeriodicalTrigger) UnmarshalJSON(data []byte) error {
+	var v periodicalTrigger
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	start, err := time.ParseInLocation(serverTimeLayout, v.Start, serverLocation)
+	if err != nil {
+		return err
+	}
+
+	end, err := time.ParseInLocation(serverTimeLayout, v.End, serverLocation)
+	if err != nil {
+		return err
+	}
+
+	*p = PeriodicalTrigger{
+		Start:     start,
+		End:       end,
+		Time:      v.Time,
+		TimeUnit:  v.TimeUnit,
+		Frequency: v.Frequency,
+		Point:     v.Point,
+		Location:  serverLocation,
+	}
+	return nil
+}
+
+func dayNumber(t time.Time) int {
+	y, m, d := t.Date()
+	return int(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / 86400)
+}
+
+// pointIndex 返回时间点的序号(week: 0-6，month: 0-30)
+func pointIndex(unit TimeUnit, point string) (int, bool) {
+	switch unit {
+	case TimeUnitWeek:
+		for i, v := range weekPoints {
+			if v == point {
+				return i, true
+			}
+		}
+	case TimeUnitMonth:
+		if len(point) != 2 {
+			return 0, false
+		}
+		day, err := strconv.Atoi(point)
+		if err == nil && day >= 1 && day <= 31 {
+			return day - 1, true
+		}
+	}
+	return 0, false
+}
+
+// shiftPoint 将时间点平移 shift 天(month 的时间点由 Validate 保证平移后不跨月)
+func shiftPoint(unit TimeUnit, point string, shift int) string {
+	i, ok := pointIndex(unit, point)
+	if !ok || shift == 0 {
+		return point
+	}
+
+	switch unit {
+	case TimeUnitWeek:
+		return weekPoints[((i+shift)%7+7)%7]
+	case TimeUnitMonth:
+		return fmt.Sprintf("%02d", i+shift+1)
+	}
+	return point
+}
@@ -0,0 +1,225 @@
+package jpush
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 未返回重置时间时，频次超出限制后的默认暂停时长
+const defaultRateLimitPause = time.Second
+
+// newHeaderItem 解析响应头中的频率限制信息，不存在时返回 nil
+func newHeaderItem(header http.Header) *HeaderItem {
+	if header.Get("X-Rate-Limit-Quota") == "" {
+		return nil
+	}
+
+	item := new(HeaderItem)
+	item.XRateLimitQuota, _ = strconv.Atoi(header.Get("X-Rate-Limit-Quota"))
+	item.XRateLimitRemaining, _ = strconv.Atoi(header.Get("X-Rate-Limit-Remaining"))
+	item.XRateLimitReset, _ = strconv.Atoi(header.Get("X-Rate-Limit-Reset"))
+	return item
+}
+
+// 含路径参数的接口，参数段以 ":" 开头(匹配时优先于参数段的固定路径在 fixedEndpoints 中列出)
+var endpointTemplates = []string{
+	"/v3/push/:msg_id",
+	"/v3/schedules/:schedule_id",
+	"/v3/devices/:registration_id",
+	"/v3/tags/:tag",
+	"/v3/tags/:tag/registration_ids/:registration_id",
+	"/v3/aliases/:alias",
+	"/v3/files/:file_id",
+	"/v3/images/byurls/:media_id",
+	"/v3/images/byfiles/:media_id",
+}
+
+// 与 endpointTemplates 路径形式相同的固定接口
+var fixedEndpoints = map[string]bool{
+	"/v3/push/cid":              true,
+	"/v3/push/validate":         true,
+	"/v3/push/file":             true,
+	"/v3/devices/status":        true,
+	"/v3/files/registration_id": true,
+	"/v3/files/alias":           true,
+}
+
+// routerEndpoint 获取请求路由所属的接口(如 /v3/push/cid、/v3/schedules/:schedule_id)，
+// 频率限制按接口统计，路径参数替换为模板中的参数名
+func routerEndpoint(router string) string {
+	if i := strings.IndexByte(router, '?'); i >= 0 {
+		router = router[:i]
+	}
+	if fixedEndpoints[router] {
+		return router
+	}
+
+	parts := strings.Split(router, "/")
+	for _, tpl := range endpointTemplates {
+		if matchEndpoint(strings.Split(tpl, "/"), parts) {
+			return tpl
+		}
+	}
+	return router
+}
+
+func matchEndpoint(tpl, parts []string) bool {
+	if len(tpl) != len(parts) {
+		return false
+	}
+	for i, seg := range tpl {
+		if !strings.HasPrefix(seg, ":") && seg != parts[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// QuotaItem 接口的频率限制信息
+type QuotaItem struct {
+	Endpoint   string     // 接口(如 /v3/push、/v3/devices/:registration_id)
+	HeaderItem HeaderItem // 最近一次响应头中的频率限制信息
+	UpdatedAt  time.Time  // 更新时间
+}
+
+// ResetAt 配额重置时间
+func (q *QuotaItem) ResetAt() time.Time {
+	return q.UpdatedAt.Add(time.Second * time.Duration(q.HeaderItem.XRateLimitReset))
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		items: make(map[string]*rateLimitItem),
+	}
+}
+
+// rateLimiter 客户端内共享的频率限制器，按接口记录最近一次的频率限制信息
+type rateLimiter struct {
+	lock  sync.Mutex
+	items map[string]*rateLimitItem
+}
+
+type rateLimitItem struct {
+	header      HeaderItem
+	updatedAt   time.Time
+	pausedUntil time.Time
+	interval    time.Duration
+	next        time.Time
+}
+
+func (l *rateLimiter) item(endpoint string) *rateLimitItem {
+	item, ok := l.items[endpoint]
+	if !ok {
+		item = new(rateLimitItem)
+		l.items[endpoint] = item
+	}
+	return item
+}
+
+// Update 根据响应结果更新频率限制，limited 表示请求因频次超出限制被拒绝
+func (l *rateLimiter) Update(endpoint string, header *HeaderItem, limited bool) {
+	if l == nil || (header == nil && !limited) {
+		return
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	item := l.item(endpoint)
+
+	if header == nil {
+		item.pausedUntil = now.Add(defaultRateLimitPause)
+		return
+	}
+
+	item.header = *header
+	item.updatedAt = now
+	reset := time.Second * time.Duration(header.XRateLimitReset)
+
+	// 配额用尽时暂停到重置时间，否则将剩余配额均匀分布到重置前的时间窗口内
+	if limited || header.XRateLimitRemaining <= 0 {
+		if reset <= 0 {
+			reset = defaultRateLimitPause
+		}
+		item.pausedUntil = now.Add(reset)
+		item.interval = 0
+	} else if reset > 0 {
+		item.interval = reset / time.Duration(header.XRateLimitRemaining)
+	} else {
+		item.interval = 0
+	}
+}
+
+// Reserve 为一次请求预留发送时间，返回需要等待的时长
+func (l *rateLimiter) Reserve(endpoint string) time.Duration {
+	if l == nil {
+		return 0
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := time.Now()
+	item := l.item(endpoint)
+
+	// 时间窗口已重置，之前的配额信息不再有效
+	if item.interval > 0 && now.After(item.updatedAt.Add(time.Second*time.Duration(item.header.XRateLimitReset))) {
+		item.interval = 0
+	}
+
+	start := now
+	if item.pausedUntil.After(start) {
+		start = item.pausedUntil
+	}
+	if item.interval > 0 {
+		if item.next.After(start) {
+			start = item.next
+		}
+		item.next = start.Add(item.interval)
+	}
+	return start.Sub(now)
+}
+
+// Snapshot 获取各接口最近一次的频率限制信息
+func (l *rateLimiter) Snapshot() map[string]*QuotaItem {
+	snapshot := make(map[string]*QuotaItem)
+	if l == nil {
+		return snapshot
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	for endpoint, item := range l.items {
+		if item.updatedAt.IsZero() {
+			continue
+		}
+		snapshot[endpoint] = &QuotaItem{
+			Endpoint:   endpoint,
+			HeaderItem: item.header,
+			UpdatedAt:  item.updatedAt,
+		}
+	}
+	return snapshot
+}
+
+// Paused 返回接口因频次超出限制而需要暂停的剩余时长
+func (l *rateLimiter) Paused(endpoint string) time.Duration {
+	if l == nil {
+		return 0
+	}
+
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	if item, ok := l.items[endpoint]; ok {
+		if d := time.Until(item.pausedUntil); d > 0 {
+			return d
+		}
+	}
+	return 0
+}
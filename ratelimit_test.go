@@ -0,0 +1,43 @@
+package jpush
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRateLimiter(t *testing.T) {
+	Convey("test rate limiter", t, func() {
+		So(routerEndpoint("/v3/push"), ShouldEqual, "/v3/push")
+		So(routerEndpoint("/v3/push/cid?type=push&count=1"), ShouldEqual, "/v3/push/cid")
+		So(routerEndpoint("/v3/push/validate"), ShouldEqual, "/v3/push/validate")
+		So(routerEndpoint("/v3/push/batch/regid/single"), ShouldEqual, "/v3/push/batch/regid/single")
+		So(routerEndpoint("/v3/push/123456"), ShouldEqual, "/v3/push/:msg_id")
+		So(routerEndpoint("/v3/schedules?page=1"), ShouldEqual, "/v3/schedules")
+		So(routerEndpoint("/v3/schedules/abc"), ShouldEqual, "/v3/schedules/:schedule_id")
+		So(routerEndpoint("/v3/devices/status"), ShouldEqual, "/v3/devices/status")
+		So(routerEndpoint("/v3/devices/rid-1"), ShouldEqual, "/v3/devices/:registration_id")
+		So(routerEndpoint("/v3/tags/t1/registration_ids/rid-1"), ShouldEqual, "/v3/tags/:tag/registration_ids/:registration_id")
+		So(routerEndpoint("/v3/files/alias"), ShouldEqual, "/v3/files/alias")
+		So(routerEndpoint("/v3/files/f-1"), ShouldEqual, "/v3/files/:file_id")
+
+		limiter := newRateLimiter()
+		So(limiter.Reserve("/v3/push"), ShouldEqual, 0)
+
+		limiter.Update("/v3/push", &HeaderItem{XRateLimitQuota: 600, XRateLimitRemaining: 10, XRateLimitReset: 10}, false)
+		So(limiter.Reserve("/v3/push"), ShouldEqual, 0)
+		So(limiter.Reserve("/v3/push"), ShouldBeBetween, time.Millisecond*900, time.Second)
+		So(limiter.Paused("/v3/push"), ShouldEqual, 0)
+
+		limiter.Update("/v3/push", &HeaderItem{XRateLimitQuota: 600, XRateLimitReset: 5}, true)
+		So(limiter.Paused("/v3/push"), ShouldBeBetween, time.Second*4, time.Second*5+1)
+		So(limiter.Reserve("/v3/push"), ShouldBeBetween, time.Second*4, time.Second*5+1)
+		So(limiter.Reserve("/v3/schedules"), ShouldEqual, 0)
+
+		quota := limiter.Snapshot()
+		So(quota, ShouldHaveLength, 1)
+		So(quota["/v3/push"].HeaderItem.XRateLimitQuota, ShouldEqual, 600)
+		So(quota["/v3/push"].ResetAt(), ShouldHappenAfter, time.Now())
+	})
+}
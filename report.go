@@ -0,0 +1,346 @@
+package jpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/LyricTian/req"
+)
+
+var (
+	// ErrInvalidMsgID 无效的 msg_id
+	ErrInvalidMsgID = errors.New("invalid msg_id")
+)
+
+// 单次请求查询的 msg_id 及注册 ID 的最大数量
+const (
+	reportBatchSize        = 100
+	messageStatusBatchSize = 1000
+)
+
+const messageStatusEndpoint = "/v3/status/message"
+
+// 消息送达状态
+const (
+	MessageStatusReceived    = 0 // 送达
+	MessageStatusNotReceived = 1 // 未送达
+	MessageStatusInvalid     = 2 // 注册 ID 不属于该应用
+	MessageStatusNotTarget   = 3 // 注册 ID 属于该应用，但不是该消息的推送目标
+	MessageStatusError       = 4 // 系统异常
+)
+
+// MsgIDs 获取推送结果中的 msg_id(忽略空值)
+func MsgIDs(results []*PushResult) []string {
+	var msgIDs []string
+	for _, result := range results {
+		if result != nil && result.MsgID != "" {
+			msgIDs = append(msgIDs, result.MsgID)
+		}
+	}
+	return msgIDs
+}
+
+// NewReportClient 创建统计客户端实例
+func NewReportClient(opts ...Option) *ReportClient {
+	return newReportClient(newOptions(opts...))
+}
+
+func newReportClient(opts *options) *ReportClient {
+	return &ReportClient{
+		opts: opts,
+	}
+}
+
+// ReportClient 统计客户端
+type ReportClient struct {
+	opts *options
+}
+
+// msgQuery 按 msg_id 分批查询，每批成功的响应交由 decode 解析，部分批次失败时返回 *BulkError
+func (c *ReportClient) msgQuery(ctx context.Context, router string, msgIDs []string, decode func(req.Responser) error) error {
+	var results []*BatchResult
+	for _, items := range chunkStrings(msgIDs, reportBatchSize) {
+		result := &BatchResult{Items: items}
+		results = append(results, result)
+
+		if err := ctx.Err(); err != nil {
+			result.Err = err
+			continue
+		}
+
+		params := make(url.Values)
+		params.Set("msg_ids", strings.Join(items, ","))
+		urlStr := fmt.Sprintf("%s?%s", router, params.Encode())
+
+		result.Err = retryRequest(ctx, c.opts, routerEndpoint(router), func() error {
+			resp, err := reportRequest(ctx, c.opts, urlStr, http.MethodGet, nil)
+			if err != nil {
+				return err
+			}
+			return decode(resp)
+		})
+	}
+	return newBulkError(results)
+}
+
+// Received 查询送达统计
+func (c *ReportClient) Received(ctx context.Context, msgIDs ...string) ([]*ReceivedResult, error) {
+	var results []*ReceivedResult
+	err := c.msgQuery(ctx, "/v3/received", msgIDs, func(resp req.Responser) error {
+		var batch []*ReceivedResult
+		if err := resp.JSON(&batch); err != nil {
+			return err
+		}
+		header := newHeaderItem(resp.Response().Header)
+		for _, item := range batch {
+			item.HeaderItem = header
+		}
+		results = append(results, batch...)
+		return nil
+	})
+	return results, err
+}
+
+// ReceivedByResults 按推送结果查询送达统计
+func (c *ReportClient) ReceivedByResults(ctx context.Context, results []*PushResult) ([]*ReceivedResult, error) {
+	return c.Received(ctx, MsgIDs(results)...)
+}
+
+// ReceivedDetail 查询送达统计详情(含厂商通道)
+func (c *ReportClient) ReceivedDetail(ctx context.Context, msgIDs ...string) ([]*ReceivedDetail, error) {
+	var results []*ReceivedDetail
+	err := c.msgQuery(ctx, "/v3/received/detail", msgIDs, func(resp req.Responser) error {
+		var batch []*ReceivedDetail
+		if err := resp.JSON(&batch); err != nil {
+			return err
+		}
+		header := newHeaderItem(resp.Response().Header)
+		for _, item := range batch {
+			item.HeaderItem = header
+		}
+		results = append(results, batch...)
+		return nil
+	})
+	return results, err
+}
+
+// ReceivedDetailByResults 按推送结果查询送达统计详情
+func (c *ReportClient) ReceivedDetailByResults(ctx context.Context, results []*PushResult) ([]*ReceivedDetail, error) {
+	return c.ReceivedDetail(ctx, MsgIDs(results)...)
+}
+
+// Messages 查询消息统计
+func (c *ReportClient) Messages(ctx context.Context, msgIDs ...string) ([]*MessageResult, error) {
+	var results []*MessageResult
+	err := c.msgQuery(ctx, "/v3/messages", msgIDs, func(resp req.Responser) error {
+		var batch []*MessageResult
+		if err := resp.JSON(&batch); err != nil {
+			return err
+		}
+		header := newHeaderItem(resp.Response().Header)
+		for _, item := range batch {
+			item.HeaderItem = header
+		}
+		results = append(results, batch...)
+		return nil
+	})
+	return results, err
+}
+
+// MessagesByResults 按推送结果查询消息统计
+func (c *ReportClient) MessagesByResults(ctx context.Context, results []*PushResult) ([]*MessageResult, error) {
+	return c.Messages(ctx, MsgIDs(results)...)
+}
+
+// MessagesDetail 查询消息统计详情(含厂商通道)
+func (c *ReportClient) MessagesDetail(ctx context.Context, msgIDs ...string) ([]*MessageDetail, error) {
+	var results []*MessageDetail
+	err := c.msgQuery(ctx, "/v3/messages/detail", msgIDs, func(resp req.Responser) error {
+		var batch []*MessageDetail
+		if err := resp.JSON(&batch); err != nil {
+			return err
+		}
+		header := newHeaderItem(resp.Response().Header)
+		for _, item := range batch {
+			item.HeaderItem = header
+		}
+		results = append(results, batch...)
+		return nil
+	})
+	return results, err
+}
+
+// MessagesDetailByResults 按推送结果查询消息统计详情
+func (c *ReportClient) MessagesDetailByResults(ctx context.Context, results []*PushResult) ([]*MessageDetail, error) {
+	return c.MessagesDetail(ctx, MsgIDs(results)...)
+}
+
+// MessageStatus 查询消息在设备上的送达状态(date 为零值时查询当天)，按接口限制自动分批
+func (c *ReportClient) MessageStatus(ctx context.Context, msgID string, date time.Time, registrationIDs ...string) (map[string]*MessageStatus, error) {
+	// 请求体中的 msg_id 为数字
+	if _, err := strconv.ParseUint(msgID, 10, 64); err != nil {
+		return nil, ErrInvalidMsgID
+	}
+
+	statuses := make(map[string]*MessageStatus)
+
+	var results []*BatchResult
+	for _, items := range chunkStrings(registrationIDs, messageStatusBatchSize) {
+		result := &BatchResult{Items: items}
+		results = append(results, result)
+
+		if err := ctx.Err(); err != nil {
+			result.Err = err
+			continue
+		}
+
+		v := map[string]interface{}{
+			"msg_id":           json.Number(msgID),
+			"registration_ids": items,
+		}
+		if !date.IsZero() {
+			v["date"] = date.In(serverLocation).Format("2006-01-02")
+		}
+
+		body, err := json.Marshal(v)
+		if err != nil {
+			result.Err = err
+			continue
+		}
+
+		var batch map[string]*MessageStatus
+		result.Err = retryRequest(ctx, c.opts, messageStatusEndpoint, func() error {
+			resp, err := reportRequest(ctx, c.opts, messageStatusEndpoint, http.MethodPost, bytes.NewReader(body))
+			if err != nil {
+				return err
+			}
+			if err := resp.JSON(&batch); err != nil {
+				return err
+			}
+			header := newHeaderItem(resp.Response().Header)
+			for _, status := range batch {
+				status.HeaderItem = header
+			}
+			return nil
+		})
+		for id, status := range batch {
+			statuses[id] = status
+		}
+	}
+
+	return statuses, newBulkError(results)
+}
+
+// ReceivedResult 送达统计
+type ReceivedResult struct {
+	MsgID           json.Number `json:"msg_id"`
+	AndroidReceived int         `json:"android_received"`  // Android 送达数
+	IOSApnsSent     int         `json:"ios_apns_sent"`     // iOS APNs 推送成功数
+	IOSApnsReceived int         `json:"ios_apns_received"` // iOS APNs 送达数
+	IOSMsgReceived  int         `json:"ios_msg_received"`  // iOS 自定义消息送达数
+	WPMpnsSent      int         `json:"wp_mpns_sent"`      // Windows Phone 推送成功数
+	HeaderItem      *HeaderItem `json:"-"`
+}
+
+// ReceivedDetail 送达统计详情
+type ReceivedDetail struct {
+	MsgID                 json.Number `json:"msg_id"`
+	JPushReceived         int         `json:"jpush_received"`          // 极光通道送达数
+	AndroidPNSSent        int         `json:"android_pns_sent"`        // Android 厂商通道推送成功数
+	AndroidPNSReceived    int         `json:"android_pns_received"`    // Android 厂商通道送达数
+	IOSApnsSent           int         `json:"ios_apns_sent"`           // iOS APNs 推送成功数
+	IOSApnsReceived       int         `json:"ios_apns_received"`       // iOS APNs 送达数
+	IOSMsgReceived        int         `json:"ios_msg_received"`        // iOS 自定义消息送达数
+	QuickAppJPushReceived int         `json:"quickapp_jpush_received"` // 快应用极光通道送达数
+	QuickAppPNSSent       int         `json:"quickapp_pns_sent"`       // 快应用厂商通道推送成功数
+	HeaderItem            *HeaderItem `json:"-"`
+}
+
+// MessageResult 消息统计
+type MessageResult struct {
+	MsgID      json.Number      `json:"msg_id"`
+	Android    *AndroidMessage  `json:"android,omitempty"`
+	IOS        *IOSMessage      `json:"ios,omitempty"`
+	WinPhone   *WinPhoneMessage `json:"winphone,omitempty"`
+	HeaderItem *HeaderItem      `json:"-"`
+}
+
+// AndroidMessage Android 平台的消息统计
+type AndroidMessage struct {
+	Received   int `json:"received"`    // 送达数
+	Target     int `json:"target"`      // 目标数
+	OnlinePush int `json:"online_push"` // 在线推送数
+	Click      int `json:"click"`       // 通知点击数
+	MsgClick   int `json:"msg_click"`   // 自定义消息点击数
+}
+
+// IOSMessage iOS 平台的消息统计
+type IOSMessage struct {
+	ApnsSent     int `json:"apns_sent"`     // APNs 推送成功数
+	ApnsTarget   int `json:"apns_target"`   // APNs 目标数
+	ApnsReceived int `json:"apns_received"` // APNs 送达数
+	Click        int `json:"click"`         // 通知点击数
+	Target       int `json:"target"`        // 自定义消息目标数
+	Received     int `json:"received"`      // 自定义消息送达数
+	MsgClick     int `json:"msg_click"`     // 自定义消息点击数
+}
+
+// WinPhoneMessage Windows Phone 平台的消息统计
+type WinPhoneMessage struct {
+	MpnsTarget int `json:"mpns_target"` // 目标数
+	MpnsSent   int `json:"mpns_sent"`   // 推送成功数
+	Click      int `json:"click"`       // 点击数
+}
+
+// MessageDetail 消息统计详情
+type MessageDetail struct {
+	MsgID      json.Number          `json:"msg_id"`
+	Details    *MessageDetailCounts `json:"details"`
+	HeaderItem *HeaderItem          `json:"-"`
+}
+
+// MessageDetailCounts 消息统计详情中的各通道数据
+type MessageDetailCounts struct {
+	Notification *ReportCounter    `json:"notification,omitempty"` // 通知
+	Message      *ReportCounter    `json:"message,omitempty"`      // 自定义消息
+	JPush        *ReportCounter    `json:"jpush,omitempty"`        // 极光通道
+	AndroidPNS   *AndroidPNSDetail `json:"android_pns,omitempty"`  // Android 厂商通道
+	IOS          *ReportCounter    `json:"ios,omitempty"`          // iOS APNs 通道
+}
+
+// ReportCounter 通道统计数据
+type ReportCounter struct {
+	Target      int `json:"target"`      // 目标数
+	Sent        int `json:"sent"`        // 推送成功数
+	Received    int `json:"received"`    // 送达数
+	Impressions int `json:"impressions"` // 展示数
+	Clicks      int `json:"clicks"`      // 点击数
+}
+
+// AndroidPNSDetail Android 厂商通道的统计数据
+type AndroidPNSDetail struct {
+	PNSTarget   int            `json:"pns_target"`             // 厂商通道目标数
+	PNSSent     int            `json:"pns_sent"`               // 厂商通道推送成功数
+	PNSReceived int            `json:"pns_received"`           // 厂商通道送达数
+	Xiaomi      *ReportCounter `json:"xm_detail,omitempty"`    // 小米
+	Huawei      *ReportCounter `json:"hw_detail,omitempty"`    // 华为
+	Honor       *ReportCounter `json:"honor_detail,omitempty"` // 荣耀
+	OPPO        *ReportCounter `json:"oppo_detail,omitempty"`  // OPPO
+	Vivo        *ReportCounter `json:"vivo_detail,omitempty"`  // vivo
+	Meizu       *ReportCounter `json:"mz_detail,omitempty"`    // 魅族
+	FCM         *ReportCounter `json:"fcm_detail,omitempty"`   // FCM
+}
+
+// MessageStatus 消息在设备上的送达状态
+type MessageStatus struct {
+	Status     int         `json:"status"`
+	HeaderItem *HeaderItem `json:"-"`
+}
@@ -0,0 +1,115 @@
+package jpush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestReportClient(t *testing.T) {
+	Convey("test report client", t, func() {
+		var (
+			paths []string
+			sizes []int
+			body  map[string]json.RawMessage
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.Method+" "+r.URL.Path)
+			w.Header().Set("X-Rate-Limit-Quota", "600")
+			w.Header().Set("X-Rate-Limit-Remaining", "599")
+			w.Header().Set("X-Rate-Limit-Reset", "60")
+
+			if r.URL.Path == messageStatusEndpoint {
+				json.NewDecoder(r.Body).Decode(&body)
+				w.Write([]byte(`{"rid-1":{"status":0},"rid-2":{"status":1}}`))
+				return
+			}
+
+			msgIDs := strings.Split(r.URL.Query().Get("msg_ids"), ",")
+			sizes = append(sizes, len(msgIDs))
+
+			items := make([]string, len(msgIDs))
+			for i, id := range msgIDs {
+				switch r.URL.Path {
+				case "/v3/received":
+					items[i] = fmt.Sprintf(`{"msg_id":%s,"android_received":1,"ios_apns_sent":2}`, id)
+				case "/v3/received/detail":
+					items[i] = fmt.Sprintf(`{"msg_id":%s,"jpush_received":3,"android_pns_received":4}`, id)
+				case "/v3/messages":
+					items[i] = fmt.Sprintf(`{"msg_id":%s,"android":{"received":5,"target":6},"ios":{"apns_sent":7}}`, id)
+				case "/v3/messages/detail":
+					items[i] = fmt.Sprintf(`{"msg_id":%s,"details":{"notification":{"target":8},`+
+						`"android_pns":{"pns_target":9,"xm_detail":{"sent":10},"hw_detail":{"received":11}}}}`, id)
+				}
+			}
+			w.Write([]byte("[" + strings.Join(items, ",") + "]"))
+		}))
+		defer srv.Close()
+
+		cli := NewReportClient(SetReportHost(srv.URL), SetAppKey(appKey), SetMasterSecret(masterSecret))
+		ctx := context.Background()
+
+		msgIDs := make([]string, reportBatchSize+50)
+		for i := range msgIDs {
+			msgIDs[i] = fmt.Sprintf("%d", 18100000000000000+i)
+		}
+
+		received, err := cli.Received(ctx, msgIDs...)
+		So(err, ShouldBeNil)
+		So(paths, ShouldResemble, []string{"GET /v3/received", "GET /v3/received"})
+		So(sizes, ShouldResemble, []int{reportBatchSize, 50})
+		So(received, ShouldHaveLength, len(msgIDs))
+		So(received[0].MsgID, ShouldEqual, json.Number("18100000000000000"))
+		So(received[149].MsgID, ShouldEqual, json.Number(msgIDs[149]))
+		So(received[0].AndroidReceived, ShouldEqual, 1)
+		So(received[0].IOSApnsSent, ShouldEqual, 2)
+		So(received[0].HeaderItem.XRateLimitRemaining, ShouldEqual, 599)
+
+		receivedDetail, err := cli.ReceivedDetailByResults(ctx, []*PushResult{{MsgID: "1"}, nil, {}})
+		So(err, ShouldBeNil)
+		So(receivedDetail, ShouldHaveLength, 1)
+		So(receivedDetail[0].MsgID, ShouldEqual, json.Number("1"))
+		So(receivedDetail[0].JPushReceived, ShouldEqual, 3)
+		So(receivedDetail[0].AndroidPNSReceived, ShouldEqual, 4)
+
+		messages, err := cli.Messages(ctx, "2")
+		So(err, ShouldBeNil)
+		So(messages[0].Android.Received, ShouldEqual, 5)
+		So(messages[0].Android.Target, ShouldEqual, 6)
+		So(messages[0].IOS.ApnsSent, ShouldEqual, 7)
+		So(messages[0].WinPhone, ShouldBeNil)
+		So(messages[0].HeaderItem, ShouldNotBeNil)
+
+		messagesDetail, err := cli.MessagesDetail(ctx, "3")
+		So(err, ShouldBeNil)
+		details := messagesDetail[0].Details
+		So(details.Notification.Target, ShouldEqual, 8)
+		So(details.AndroidPNS.PNSTarget, ShouldEqual, 9)
+		So(details.AndroidPNS.Xiaomi.Sent, ShouldEqual, 10)
+		So(details.AndroidPNS.Huawei.Received, ShouldEqual, 11)
+		So(details.AndroidPNS.OPPO, ShouldBeNil)
+
+		date := time.Date(2024, 5, 1, 20, 0, 0, 0, time.UTC)
+		statuses, err := cli.MessageStatus(ctx, "18100520389213431", date, "rid-1", "rid-2")
+		So(err, ShouldBeNil)
+		So(string(body["msg_id"]), ShouldEqual, "18100520389213431")
+		So(string(body["registration_ids"]), ShouldEqual, `["rid-1","rid-2"]`)
+		So(string(body["date"]), ShouldEqual, `"2024-05-02"`)
+		So(statuses["rid-1"].Status, ShouldEqual, MessageStatusReceived)
+		So(statuses["rid-2"].Status, ShouldEqual, MessageStatusNotReceived)
+		So(statuses["rid-2"].HeaderItem.XRateLimitRemaining, ShouldEqual, 599)
+
+		n := len(paths)
+		_, err = cli.MessageStatus(ctx, "", time.Time{}, "rid-1")
+		So(errors.Is(err, ErrInvalidMsgID), ShouldBeTrue)
+		So(paths, ShouldHaveLength, n)
+	})
+}
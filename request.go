@@ -4,7 +4,8 @@ import (
 	"context"
 	"encoding/json"
 	"io"
-	"strconv"
+	"mime/multipart"
+	"net/http"
 
 	"github.com/LyricTian/req"
 )
@@ -44,42 +45,104 @@ type HeaderItem struct {
 
 // jpush request
 func pushRequest(ctx context.Context, opts *options, router, method string, body io.Reader) (req.Responser, error) {
-	urlStr := req.RequestURL(opts.host, router)
+	return hostRequest(ctx, opts, opts.host, router, method, body)
+}
+
+// device request
+func deviceRequest(ctx context.Context, opts *options, router, method string, body io.Reader) (req.Responser, error) {
+	return hostRequest(ctx, opts, opts.deviceHost, router, method, body)
+}
+
+// report request
+func reportRequest(ctx context.Context, opts *options, router, method string, body io.Reader) (req.Responser, error) {
+	return hostRequest(ctx, opts, opts.reportHost, router, method, body)
+}
+
+func hostRequest(ctx context.Context, opts *options, host, router, method string, body io.Reader) (req.Responser, error) {
+	urlStr := req.RequestURL(host, router)
 	resp, err := req.Do(ctx, urlStr, method, body, req.SetBasicAuth(opts.appKey, opts.masterSecret))
 	if err != nil {
 		return nil, err
-	} else if code := resp.StatusCode(); code != 200 {
-		e := &Error{
-			StatusCode: code,
-		}
+	}
 
-		var result struct {
-			Error *ErrorItem `json:"error"`
-		}
+	header := newHeaderItem(resp.Response().Header)
+	opts.limiter.Update(routerEndpoint(router), header, resp.StatusCode() == 429)
 
+	if code := resp.StatusCode(); code != 200 {
 		buf, err := resp.Bytes()
-		if err != nil {
-			e.ErrorItem = NewErrorItem(0, err.Error())
-			return nil, e
-		}
+		return nil, newResponseError(code, header, buf, err)
+	}
 
-		err = json.Unmarshal(buf, &result)
-		if err != nil {
-			e.ErrorItem = NewErrorItem(0, string(buf))
-			return nil, e
+	return resp, nil
+}
+
+// newResponseError 根据非 200 响应构建错误
+func newResponseError(code int, header *HeaderItem, buf []byte, readErr error) *Error {
+	e := &Error{
+		StatusCode: code,
+	}
+
+	if code == 429 {
+		if header == nil {
+			header = new(HeaderItem)
 		}
-		e.ErrorItem = result.Error
-
-		if code == 429 {
-			header := new(HeaderItem)
-			header.XRateLimitQuota, _ = strconv.Atoi(resp.Response().Header.Get("X-Rate-Limit-Quota"))
-			header.XRateLimitRemaining, _ = strconv.Atoi(resp.Response().Header.Get("X-Rate-Limit-Remaining"))
-			header.XRateLimitReset, _ = strconv.Atoi(resp.Response().Header.Get("X-Rate-Limit-Reset"))
-			e.HeaderItem = header
+		e.HeaderItem = header
+	}
+
+	if readErr != nil {
+		e.ErrorItem = NewErrorItem(0, readErr.Error())
+		return e
+	}
+
+	var result struct {
+		Error *ErrorItem `json:"error"`
+	}
+	err := json.Unmarshal(buf, &result)
+	if err != nil {
+		e.ErrorItem = NewErrorItem(0, string(buf))
+		return e
+	}
+	e.ErrorItem = result.Error
+
+	return e
+}
+
+// multipartRequest 以 multipart/form-data 流式上传表单(由 write 写入)，并将响应解析到 result
+func multipartRequest(ctx context.Context, opts *options, router, method string, write func(*multipart.Writer) error, result interface{}) (*HeaderItem, error) {
+	pr, pw := io.Pipe()
+	defer pr.Close()
+
+	mw := multipart.NewWriter(pw)
+	go func() {
+		err := write(mw)
+		if err == nil {
+			err = mw.Close()
 		}
+		pw.CloseWithError(err)
+	}()
+
+	request, err := http.NewRequestWithContext(ctx, method, req.RequestURL(opts.host, router), pr)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", mw.FormDataContentType())
+	request.SetBasicAuth(opts.appKey, opts.masterSecret)
 
-		return nil, e
+	resp, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
 	}
+	defer resp.Body.Close()
 
-	return resp, nil
+	header := newHeaderItem(resp.Header)
+	opts.limiter.Update(routerEndpoint(router), header, resp.StatusCode == 429)
+
+	buf, err := io.ReadAll(resp.Body)
+	if resp.StatusCode != 200 {
+		return header, newResponseError(resp.StatusCode, header, buf, err)
+	} else if err != nil {
+		return header, err
+	}
+
+	return header, json.Unmarshal(buf, result)
 }
@@ -0,0 +1,148 @@
+package jpush
+
+import (
+	"context"
+	"errors"
+	"math"
+	"math/rand"
+	"net"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// NewRetryPolicy 创建默认的重试策略实例
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts:     5,
+		MinBackoff:      time.Second,
+		MaxBackoff:      time.Minute,
+		Multiplier:      2,
+		Jitter:          0.2,
+		RetryableStatus: DefaultRetryableStatus,
+		RetryableCode:   DefaultRetryableCode,
+		RetryableError:  DefaultRetryableError,
+	}
+}
+
+// RetryPolicy 重试策略
+type RetryPolicy struct {
+	MaxAttempts     int                       // 最大尝试次数(包含首次请求)，小于 1 时不限制
+	MinBackoff      time.Duration             // 首次重试的等待时长
+	MaxBackoff      time.Duration             // 最大等待时长
+	Multiplier      float64                   // 等待时长的增长倍数
+	Jitter          float64                   // 等待时长的随机抖动比例(0-1)
+	RetryableStatus func(statusCode int) bool // 按 HTTP 状态码判断是否可重试
+	RetryableCode   func(code int) bool       // 按 JPush 错误码判断是否可重试
+	RetryableError  func(err error) bool      // 按网络等非接口错误判断是否可重试
+}
+
+// Retryable 判断错误是否可重试
+func (p *RetryPolicy) Retryable(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var e *Error
+	if errors.As(err, &e) {
+		if p.RetryableStatus != nil && p.RetryableStatus(e.StatusCode) {
+			return true
+		}
+		return e.ErrorItem != nil && p.RetryableCode != nil && p.RetryableCode(e.ErrorItem.Code)
+	}
+
+	return p.RetryableError != nil && p.RetryableError(err)
+}
+
+// Exhausted 判断已尝试 attempts 次后是否已无重试机会
+func (p *RetryPolicy) Exhausted(attempts int) bool {
+	return p.MaxAttempts > 0 && attempts >= p.MaxAttempts
+}
+
+// Backoff 第 attempts 次尝试失败后的等待时长
+func (p *RetryPolicy) Backoff(attempts int) time.Duration {
+	if attempts < 1 {
+		attempts = 1
+	}
+
+	multiplier := p.Multiplier
+	if multiplier < 1 {
+		multiplier = 1
+	}
+
+	backoff := float64(p.MinBackoff) * math.Pow(multiplier, float64(attempts-1))
+	if p.MaxBackoff > 0 && backoff > float64(p.MaxBackoff) {
+		backoff = float64(p.MaxBackoff)
+	}
+
+	if p.Jitter > 0 {
+		backoff += backoff * p.Jitter * (rand.Float64()*2 - 1)
+	}
+	return time.Duration(backoff)
+}
+
+// DefaultRetryableStatus 默认对频率超限(429)及服务端错误(5xx)进行重试
+func DefaultRetryableStatus(statusCode int) bool {
+	return statusCode == 429 || statusCode >= 500
+}
+
+// DefaultRetryableCode 默认对系统内部错误(1000)、内部服务超时(1030)及频率超限(2002)进行重试
+func DefaultRetryableCode(code int) bool {
+	return code == 1000 || code == 1030 || code == 2002
+}
+
+// DefaultRetryableError 默认对网络超时及连接被拒绝进行重试
+func DefaultRetryableError(err error) bool {
+	var ne net.Error
+	if errors.As(err, &ne) && ne.Timeout() {
+		return true
+	}
+	return errors.Is(err, syscall.ECONNREFUSED) || strings.Contains(err.Error(), "connection refused")
+}
+
+// retryRequest 同步执行请求，发送前按频率限制等待，失败时按重试策略退避后重试
+func retryRequest(ctx context.Context, opts *options, endpoint string, fn func() error) error {
+	for attempts := 1; ; attempts++ {
+		if err := sleepContext(ctx, opts.limiter.Reserve(endpoint)); err != nil {
+			return err
+		}
+
+		err := fn()
+		policy := opts.retryPolicy
+		if err == nil || policy == nil || !policy.Retryable(err) || policy.Exhausted(attempts) {
+			return err
+		}
+
+		if err := sleepContext(ctx, policy.Backoff(attempts)); err != nil {
+			return err
+		}
+	}
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+type attemptsKey struct{}
+
+func withAttempts(ctx context.Context, attempts int) context.Context {
+	return context.WithValue(ctx, attemptsKey{}, attempts)
+}
+
+// PushAttempts 从推送回调的 ctx 中获取已尝试的次数
+func PushAttempts(ctx context.Context) int {
+	attempts, _ := ctx.Value(attemptsKey{}).(int)
+	return attempts
+}
@@ -0,0 +1,34 @@
+package jpush
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestRetryPolicy(t *testing.T) {
+	Convey("test retry policy", t, func() {
+		policy := NewRetryPolicy()
+
+		So(policy.Retryable(&Error{StatusCode: 429}), ShouldBeTrue)
+		So(policy.Retryable(&Error{StatusCode: 404}), ShouldBeFalse)
+		So(policy.Retryable(&Error{StatusCode: 400, ErrorItem: NewErrorItem(2002, "rate limit")}), ShouldBeTrue)
+		So(policy.Retryable(&Error{StatusCode: 400, ErrorItem: NewErrorItem(1003, "invalid")}), ShouldBeFalse)
+		So(policy.Retryable(errors.New("dial tcp: connection refused")), ShouldBeTrue)
+		So(policy.Retryable(context.Canceled), ShouldBeFalse)
+
+		So(policy.Exhausted(4), ShouldBeFalse)
+		So(policy.Exhausted(5), ShouldBeTrue)
+
+		policy.Jitter = 0
+		So(policy.Backoff(1), ShouldEqual, time.Second)
+		So(policy.Backoff(3), ShouldEqual, time.Second*4)
+		So(policy.Backoff(10), ShouldEqual, time.Minute)
+
+		ctx := withAttempts(context.Background(), 3)
+		So(PushAttempts(ctx), ShouldEqual, 3)
+	})
+}
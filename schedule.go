@@ -0,0 +1,257 @@
+package jpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// 定期任务触发时间的格式
+const scheduleClockLayout = "15:04:05"
+
+var (
+	// ErrInvalidTrigger 无效的触发条件
+	ErrInvalidTrigger = errors.New("invalid trigger")
+)
+
+// NewScheduleClient 创建定时任务客户端实例
+func NewScheduleClient(opts ...Option) *ScheduleClient {
+	o := newOptions(opts...)
+	return newScheduleClient(o, newCIDClient(o, o.cidCount))
+}
+
+func newScheduleClient(opts *options, cidClient *CIDClient) *ScheduleClient {
+	return &ScheduleClient{
+		opts:      opts,
+		cidClient: cidClient,
+	}
+}
+
+// ScheduleClient 定时任务客户端
+type ScheduleClient struct {
+	opts      *options
+	cidClient *CIDClient
+}
+
+// Create 创建定时任务
+func (c *ScheduleClient) Create(ctx context.Context, schedule *Schedule) (*ScheduleResult, error) {
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	if schedule.CID == "" {
+		cid, err := c.cidClient.GetScheduleID(ctx)
+		if err != nil {
+			return nil, err
+		}
+		schedule.CID = cid
+	}
+
+	resp, err := pushRequest(ctx, c.opts, "/v3/schedules", http.MethodPost, schedule.Reader())
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(ScheduleResult)
+	err = resp.JSON(result)
+	if err != nil {
+		return nil, err
+	}
+	result.HeaderItem = newHeaderItem(resp.Response().Header)
+	return result, nil
+}
+
+// List 获取有效的定时任务列表(分页，从1开始)
+func (c *ScheduleClient) List(ctx context.Context, page int) (*ScheduleList, error) {
+	params := make(url.Values)
+	params.Set("page", strconv.Itoa(page))
+
+	router := fmt.Sprintf("/v3/schedules?%s", params.Encode())
+	resp, err := pushRequest(ctx, c.opts, router, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(ScheduleList)
+	err = resp.JSON(result)
+	if err != nil {
+		return nil, err
+	}
+	result.HeaderItem = newHeaderItem(resp.Response().Header)
+	return result, nil
+}
+
+// Get 获取定时任务详情
+func (c *ScheduleClient) Get(ctx context.Context, scheduleID string) (*Schedule, error) {
+	router := fmt.Sprintf("/v3/schedules/%s", url.PathEscape(scheduleID))
+	resp, err := pushRequest(ctx, c.opts, router, http.MethodGet, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(Schedule)
+	err = resp.JSON(result)
+	if err != nil {
+		return nil, err
+	}
+	result.HeaderItem = newHeaderItem(resp.Response().Header)
+	return result, nil
+}
+
+// Update 更新定时任务(提交 schedule 中的全部字段)
+func (c *ScheduleClient) Update(ctx context.Context, scheduleID string, schedule *Schedule) (*Schedule, error) {
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+
+	router := fmt.Sprintf("/v3/schedules/%s", url.PathEscape(scheduleID))
+	resp, err := pushRequest(ctx, c.opts, router, http.MethodPut, schedule.Reader())
+	if err != nil {
+		return nil, err
+	}
+
+	result := new(Schedule)
+	err = resp.JSON(result)
+	if err != nil {
+		return nil, err
+	}
+	result.HeaderItem = newHeaderItem(resp.Response().Header)
+	return result, nil
+}
+
+// Delete 删除定时任务
+func (c *ScheduleClient) Delete(ctx context.Context, scheduleID string) error {
+	router := fmt.Sprintf("/v3/schedules/%s", url.PathEscape(scheduleID))
+	resp, err := pushRequest(ctx, c.opts, router, http.MethodDelete, nil)
+	if err != nil {
+		return err
+	}
+	resp.Close()
+	return nil
+}
+
+// NewSchedule 创建定时任务实例
+func NewSchedule() *Schedule {
+	return &Schedule{
+		Enabled: true,
+	}
+}
+
+// Schedule 定时任务
+type Schedule struct {
+	CID        string   `json:"cid,omitempty"`         // 定时任务唯一标识符
+	ScheduleID string   `json:"schedule_id,omitempty"` // 定时任务 ID
+	Name       string   `json:"name,omitempty"`        // 定时任务名称
+	Enabled    bool     `json:"enabled"`               // 是否有效
+	Trigger    *Trigger `json:"trigger,omitempty"`     // 触发条件
+	Push       *Payload `json:"push,omitempty"`        // 推送载荷
+
+	HeaderItem *HeaderItem `json:"-"` // 响应头中的频率限制信息
+}
+
+func (s *Schedule) String() string {
+	buf := new(bytes.Buffer)
+	json.NewEncoder(buf).Encode(s)
+	return buf.String()
+}
+
+// Reader 序列化为 JSON 流
+func (s *Schedule) Reader() io.Reader {
+	buf := new(bytes.Buffer)
+	json.NewEncoder(buf).Encode(s)
+	return buf
+}
+
+// Validate 校验定时任务
+func (s *Schedule) Validate() error {
+	if s.Trigger == nil {
+		return fmt.Errorf("%w: trigger is required", ErrInvalidTrigger)
+	}
+	return s.Trigger.Validate()
+}
+
+// SetName 定时任务名称
+func (s *Schedule) SetName(name string) *Schedule {
+	s.Name = name
+	return s
+}
+
+// SetEnabled 设定定时任务是否有效
+func (s *Schedule) SetEnabled(enabled bool) *Schedule {
+	s.Enabled = enabled
+	return s
+}
+
+// SetTrigger 设定触发条件
+func (s *Schedule) SetTrigger(trigger *Trigger) *Schedule {
+	s.Trigger = trigger
+	return s
+}
+
+// SetPush 设定推送载荷
+func (s *Schedule) SetPush(payload *Payload) *Schedule {
+	s.Push = payload
+	return s
+}
+
+// NewSingleTrigger 创建定时触发条件实例
+func NewSingleTrigger(t time.Time) *Trigger {
+	return &Trigger{
+		Single: &SingleTrigger{
+			Time: t.In(serverLocation).Format(serverTimeLayout),
+		},
+	}
+}
+
+// NewPeriodicalTrigger 创建定期触发条件实例(periodical 可由 NewPeriodical 构建)
+func NewPeriodicalTrigger(periodical *PeriodicalTrigger) *Trigger {
+	return &Trigger{
+		Periodical: periodical,
+	}
+}
+
+// Trigger 触发条件(single 和 periodical 二选一)
+type Trigger struct {
+	Single     *SingleTrigger     `json:"single,omitempty"`     // 定时任务
+	Periodical *PeriodicalTrigger `json:"periodical,omitempty"` // 定期任务
+}
+
+// Validate 校验触发条件
+func (t *Trigger) Validate() error {
+	if (t.Single == nil) == (t.Periodical == nil) {
+		return fmt.Errorf("%w: exactly one of single and periodical must be set", ErrInvalidTrigger)
+	}
+
+	if t.Periodical != nil {
+		return t.Periodical.Validate()
+	}
+	return nil
+}
+
+// SingleTrigger 定时任务触发条件
+type SingleTrigger struct {
+	Time string `json:"time"` // 触发时间(yyyy-MM-dd HH:mm:ss)
+}
+
+// ScheduleResult 创建定时任务响应结果
+type ScheduleResult struct {
+	ScheduleID string      `json:"schedule_id"`
+	Name       string      `json:"name"`
+	HeaderItem *HeaderItem `json:"-"`
+}
+
+// ScheduleList 定时任务列表
+type ScheduleList struct {
+	TotalCount int         `json:"total_count"`
+	TotalPages int         `json:"total_pages"`
+	Page       int         `json:"page"`
+	Schedules  []*Schedule `json:"schedules"`
+	HeaderItem *HeaderItem `json:"-"`
+}
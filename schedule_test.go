@@ -0,0 +1,149 @@
+package jpush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPeriodicalTrigger(t *testing.T) {
+	Convey("test periodical trigger", t, func() {
+		utc := time.UTC
+		periodical := NewPeriodical().
+			SetStart(time.Date(2018, 6, 1, 0, 0, 0, 0, utc)).
+			SetEnd(time.Date(2018, 7, 1, 0, 0, 0, 0, utc)).
+			SetTime("20:30:00").
+			SetTimeUnit(TimeUnitWeek).
+			SetPoint("MON", "SAT").
+			SetLocation(utc)
+		So(periodical.Validate(), ShouldBeNil)
+
+		buf, err := json.Marshal(NewPeriodicalTrigger(periodical))
+		So(err, ShouldBeNil)
+
+		var result struct {
+			Periodical map[string]interface{} `json:"periodical"`
+		}
+		So(json.Unmarshal(buf, &result), ShouldBeNil)
+		So(result.Periodical["start"], ShouldEqual, "2018-06-01 08:00:00")
+		So(result.Periodical["time"], ShouldEqual, "04:30:00")
+		So(result.Periodical["point"], ShouldResemble, []interface{}{"TUE", "SUN"})
+
+		Convey("invalid values", func() {
+			So(periodical.SetPoint("01").Validate(), ShouldNotBeNil)
+			So(periodical.SetTimeUnit(TimeUnitMonth).SetPoint("1").Validate(), ShouldNotBeNil)
+			So(periodical.SetPoint("31").SetTime("8:00").Validate(), ShouldNotBeNil)
+			So(periodical.SetTime("08:00:00").SetEnd(periodical.Start).Validate(), ShouldNotBeNil)
+		})
+
+		Convey("month points shifted across a month boundary", func() {
+			periodical.SetTimeUnit(TimeUnitMonth).SetPoint("01", "15")
+			So(periodical.Validate(), ShouldBeNil)
+			buf, err := json.Marshal(periodical)
+			So(err, ShouldBeNil)
+			So(string(buf), ShouldContainSubstring, `"point":["02","16"]`)
+
+			// 平移后为 29 日，2 月(非闰年)会被跳过
+			So(errors.Is(periodical.SetPoint("28").Validate(), ErrInvalidTrigger), ShouldBeTrue)
+
+			east := time.FixedZone("UTC+10", 10*3600)
+			periodical.SetLocation(east).SetTime("01:00:00").SetPoint("02")
+			buf, err = json.Marshal(periodical)
+			So(err, ShouldBeNil)
+			So(string(buf), ShouldContainSubstring, `"point":["01"]`)
+			So(errors.Is(periodical.SetPoint("01").Validate(), ErrInvalidTrigger), ShouldBeTrue)
+
+			// 无需平移时可使用 29-31 日
+			So(periodical.SetLocation(serverLocation).SetPoint("31").Validate(), ShouldBeNil)
+		})
+	})
+}
+
+func TestScheduleClient(t *testing.T) {
+	Convey("test schedule client", t, func() {
+		var (
+			path    string
+			method  string
+			query   = make(map[string]string)
+			created map[string]interface{}
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			path = r.URL.Path
+			method = r.Method
+			w.Header().Set("X-Rate-Limit-Quota", "600")
+			w.Header().Set("X-Rate-Limit-Remaining", "599")
+			w.Header().Set("X-Rate-Limit-Reset", "60")
+
+			switch {
+			case r.URL.Path == "/v3/push/cid":
+				query["type"] = r.URL.Query().Get("type")
+				w.Write([]byte(`{"cidlist":["s-cid-1"]}`))
+			case r.URL.Path == "/v3/schedules" && r.Method == http.MethodPost:
+				json.NewDecoder(r.Body).Decode(&created)
+				w.Write([]byte(`{"schedule_id":"sid-1","name":"test"}`))
+			case r.URL.Path == "/v3/schedules" && r.Method == http.MethodGet:
+				query["page"] = r.URL.Query().Get("page")
+				w.Write([]byte(`{"total_count":1,"total_pages":1,"page":2,"schedules":[{"schedule_id":"sid-1","name":"test","enabled":true}]}`))
+			case r.Method == http.MethodDelete:
+				w.WriteHeader(http.StatusOK)
+			default:
+				w.Write([]byte(`{"schedule_id":"sid-1","name":"test","enabled":false,"trigger":{"single":{"time":"2018-06-01 08:00:00"}}}`))
+			}
+		}))
+		defer srv.Close()
+
+		cli := NewScheduleClient(SetHost(srv.URL), SetAppKey(appKey), SetMasterSecret(masterSecret))
+		ctx := context.Background()
+		trigger := NewSingleTrigger(time.Date(2018, 6, 1, 0, 0, 0, 0, time.UTC))
+
+		_, err := cli.Create(ctx, NewSchedule().SetName("test"))
+		So(errors.Is(err, ErrInvalidTrigger), ShouldBeTrue)
+
+		schedule := NewSchedule().SetName("test").SetTrigger(trigger).SetPush(&Payload{
+			Platform:     NewPlatform().All(),
+			Audience:     NewAudience().All(),
+			Notification: NewNotification().SetAlert("定时推送"),
+		})
+		result, err := cli.Create(ctx, schedule)
+		So(err, ShouldBeNil)
+		So(result.ScheduleID, ShouldEqual, "sid-1")
+		So(result.HeaderItem.XRateLimitRemaining, ShouldEqual, 599)
+		So(query["type"], ShouldEqual, "schedule")
+		So(schedule.CID, ShouldEqual, "s-cid-1")
+		So(created["cid"], ShouldEqual, "s-cid-1")
+		So(created["enabled"], ShouldBeTrue)
+		So(created["trigger"], ShouldResemble, map[string]interface{}{
+			"single": map[string]interface{}{"time": "2018-06-01 08:00:00"},
+		})
+
+		list, err := cli.List(ctx, 2)
+		So(err, ShouldBeNil)
+		So(query["page"], ShouldEqual, "2")
+		So(list.Page, ShouldEqual, 2)
+		So(list.HeaderItem.XRateLimitQuota, ShouldEqual, 600)
+		So(list.Schedules, ShouldHaveLength, 1)
+		So(list.Schedules[0].ScheduleID, ShouldEqual, "sid-1")
+
+		item, err := cli.Get(ctx, "sid-1")
+		So(err, ShouldBeNil)
+		So(path, ShouldEqual, "/v3/schedules/sid-1")
+		So(item.Trigger.Single.Time, ShouldEqual, "2018-06-01 08:00:00")
+		So(item.HeaderItem.XRateLimitReset, ShouldEqual, 60)
+
+		item, err = cli.Update(ctx, "sid-1", NewSchedule().SetEnabled(false).SetTrigger(trigger))
+		So(err, ShouldBeNil)
+		So(method, ShouldEqual, http.MethodPut)
+		So(item.Enabled, ShouldBeFalse)
+		So(item.HeaderItem, ShouldNotBeNil)
+
+		So(cli.Delete(ctx, "sid-1"), ShouldBeNil)
+		So(method, ShouldEqual, http.MethodDelete)
+		So(path, ShouldEqual, "/v3/schedules/sid-1")
+	})
+}
@@ -0,0 +1,11 @@
+package jpush
+
+import (
+	"time"
+)
+
+// 服务端的时间格式(yyyy-MM-dd HH:mm:ss)
+const serverTimeLayout = "2006-01-02 15:04:05"
+
+// 服务端所在时区(定时任务、设备状态、通知展示时间等均按该时区解析)
+var serverLocation = time.FixedZone("CST", 8*3600)
@@ -0,0 +1,140 @@
+package jpush
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// 单次请求添加或删除的注册 ID 的最大数量
+const tagBatchSize = 1000
+
+const tagEndpoint = "/v3/tags"
+
+// NewTagClient 创建标签客户端实例
+func NewTagClient(opts ...Option) *TagClient {
+	return newTagClient(newOptions(opts...))
+}
+
+func newTagClient(opts *options) *TagClient {
+	return &TagClient{
+		opts: opts,
+	}
+}
+
+// TagClient 标签客户端
+type TagClient struct {
+	opts *options
+}
+
+// List 查询标签列表
+func (c *TagClient) List(ctx context.Context) ([]string, error) {
+	var result struct {
+		Tags []string `json:"tags"`
+	}
+
+	err := retryRequest(ctx, c.opts, tagEndpoint, func() error {
+		resp, err := deviceRequest(ctx, c.opts, tagEndpoint, http.MethodGet, nil)
+		if err != nil {
+			return err
+		}
+		return resp.JSON(&result)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.Tags, nil
+}
+
+// IsMember 判断设备是否在标签下
+func (c *TagClient) IsMember(ctx context.Context, tag, registrationID string) (bool, error) {
+	var result struct {
+		Result bool `json:"result"`
+	}
+
+	router := fmt.Sprintf("/v3/tags/%s/registration_ids/%s", url.PathEscape(tag), url.PathEscape(registrationID))
+	err := retryRequest(ctx, c.opts, routerEndpoint(router), func() error {
+		resp, err := deviceRequest(ctx, c.opts, router, http.MethodGet, nil)
+		if err != nil {
+			return err
+		}
+		return resp.JSON(&result)
+	})
+	if err != nil {
+		return false, err
+	}
+	return result.Result, nil
+}
+
+// AddRegistrationIDs 为标签添加设备，按接口限制自动分批，部分批次失败时返回 *BulkError
+func (c *TagClient) AddRegistrationIDs(ctx context.Context, tag string, registrationIDs ...string) ([]*BatchResult, error) {
+	return c.update(ctx, tag, "add", registrationIDs)
+}
+
+// RemoveRegistrationIDs 从标签中删除设备，按接口限制自动分批，部分批次失败时返回 *BulkError
+func (c *TagClient) RemoveRegistrationIDs(ctx context.Context, tag string, registrationIDs ...string) ([]*BatchResult, error) {
+	return c.update(ctx, tag, "remove", registrationIDs)
+}
+
+func (c *TagClient) update(ctx context.Context, tag, action string, registrationIDs []string) ([]*BatchResult, error) {
+	router := fmt.Sprintf("/v3/tags/%s", url.PathEscape(tag))
+
+	var results []*BatchResult
+	for _, items := range chunkStrings(registrationIDs, tagBatchSize) {
+		result := &BatchResult{Items: items}
+		results = append(results, result)
+
+		// ctx 结束后剩余批次不再发送
+		if err := ctx.Err(); err != nil {
+			result.Err = err
+			continue
+		}
+
+		body, err := json.Marshal(map[string]interface{}{
+			"registration_ids": map[string][]string{action: items},
+		})
+		if err != nil {
+			result.Err = err
+			continue
+		}
+
+		result.Err = retryRequest(ctx, c.opts, routerEndpoint(router), func() error {
+			resp, err := deviceRequest(ctx, c.opts, router, http.MethodPost, bytes.NewReader(body))
+			if err != nil {
+				return err
+			}
+			resp.Close()
+			return nil
+		})
+	}
+
+	return results, newBulkError(results)
+}
+
+// Delete 删除标签及其与设备的关联(platforms 为空时删除全部平台)
+func (c *TagClient) Delete(ctx context.Context, tag string, platforms ...OS) error {
+	router := fmt.Sprintf("/v3/tags/%s", url.PathEscape(tag))
+	if len(platforms) > 0 {
+		values := make([]string, len(platforms))
+		for i, platform := range platforms {
+			values[i] = platform.String()
+		}
+
+		params := make(url.Values)
+		params.Set("platform", strings.Join(values, ","))
+		router = fmt.Sprintf("%s?%s", router, params.Encode())
+	}
+
+	return retryRequest(ctx, c.opts, routerEndpoint(router), func() error {
+		resp, err := deviceRequest(ctx, c.opts, router, http.MethodDelete, nil)
+		if err != nil {
+			return err
+		}
+		resp.Close()
+		return nil
+	})
+}
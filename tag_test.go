@@ -0,0 +1,83 @@
+package jpush
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestTagClient(t *testing.T) {
+	Convey("test tag client", t, func() {
+		var (
+			lock     sync.Mutex
+			paths    []string
+			platform string
+			bodies   []map[string]map[string][]string
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			lock.Lock()
+			defer lock.Unlock()
+			paths = append(paths, r.Method+" "+r.URL.Path)
+
+			switch r.Method {
+			case http.MethodGet:
+				w.Write([]byte(`{"result":true}`))
+			case http.MethodDelete:
+				platform = r.URL.Query().Get("platform")
+			case http.MethodPost:
+				var body map[string]map[string][]string
+				json.NewDecoder(r.Body).Decode(&body)
+				bodies = append(bodies, body)
+
+				// 第二批失败
+				if len(bodies) == 2 {
+					w.WriteHeader(http.StatusBadRequest)
+					w.Write([]byte(`{"error":{"code":7000,"message":"invalid registration id"}}`))
+				}
+			}
+		}))
+		defer srv.Close()
+
+		cli := NewTagClient(SetDeviceHost(srv.URL), SetAppKey(appKey), SetMasterSecret(masterSecret))
+		ctx := context.Background()
+
+		ok, err := cli.IsMember(ctx, "vip", "rid-1")
+		So(err, ShouldBeNil)
+		So(ok, ShouldBeTrue)
+		So(paths[0], ShouldEqual, "GET /v3/tags/vip/registration_ids/rid-1")
+
+		rids := make([]string, tagBatchSize+1)
+		for i := range rids {
+			rids[i] = "rid-" + strconv.Itoa(i)
+		}
+		results, err := cli.AddRegistrationIDs(ctx, "vip", rids...)
+		So(results, ShouldHaveLength, 2)
+		So(bodies, ShouldHaveLength, 2)
+		So(bodies[0]["registration_ids"]["add"], ShouldHaveLength, tagBatchSize)
+		So(bodies[1]["registration_ids"]["add"], ShouldResemble, []string{rids[tagBatchSize]})
+
+		var bulk *BulkError
+		So(errors.As(err, &bulk), ShouldBeTrue)
+		So(bulk.Total, ShouldEqual, 2)
+		So(bulk.Failed, ShouldHaveLength, 1)
+		So(bulk.Failed[0].Items, ShouldResemble, []string{rids[tagBatchSize]})
+		So(bulk.Failed[0].Err.(*Error).ErrorItem.Code, ShouldEqual, 7000)
+
+		_, err = cli.RemoveRegistrationIDs(ctx, "vip", "rid-1")
+		So(err, ShouldBeNil)
+		So(bodies[2], ShouldResemble, map[string]map[string][]string{
+			"registration_ids": {"remove": {"rid-1"}},
+		})
+
+		So(cli.Delete(ctx, "vip", Android, IOS), ShouldBeNil)
+		So(paths[len(paths)-1], ShouldEqual, "DELETE /v3/tags/vip")
+		So(platform, ShouldEqual, "android,ios")
+	})
+}
@@ -0,0 +1,303 @@
+package jpush
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// DeliveryHandle 投递统计的最终结果
+type DeliveryHandle func(stats *DeliveryStats)
+
+// DeliveryStats 消息的投递统计
+type DeliveryStats struct {
+	MsgID    string
+	Received *ReceivedResult // 送达统计
+	Message  *MessageResult  // 消息统计
+	Settled  bool            // 统计数据是否已稳定(否则为超时或停止跟踪)
+	Polls    int             // 查询次数
+	Err      error           // 最近一次查询的错误
+	StartAt  time.Time       // 开始跟踪的时间
+	FinishAt time.Time       // 结束跟踪的时间
+}
+
+type trackedMsg struct {
+	stats    *DeliveryStats
+	interval time.Duration
+	next     time.Time
+	deadline time.Time
+	stable   int
+}
+
+// NewDeliveryTracker 创建投递跟踪实例，按退避间隔查询已推送消息的统计数据，
+// 数据稳定或超时后将最终结果交由 handle 处理
+func NewDeliveryTracker(report *ReportClient, handle DeliveryHandle) *DeliveryTracker {
+	ctx, cancel := context.WithCancel(context.Background())
+	t := &DeliveryTracker{
+		report:      report,
+		handle:      handle,
+		minInterval: time.Second * 30,
+		maxInterval: time.Minute * 10,
+		timeout:     time.Hour * 6,
+		stablePolls: 3,
+		msgs:        make(map[string]*trackedMsg),
+		wake:        make(chan struct{}, 1),
+		ctx:         ctx,
+		cancel:      cancel,
+		done:        make(chan struct{}),
+	}
+	go t.run()
+	return t
+}
+
+// DeliveryTracker 投递跟踪
+type DeliveryTracker struct {
+	report      *ReportClient
+	handle      DeliveryHandle
+	lock        sync.Mutex
+	minInterval time.Duration
+	maxInterval time.Duration
+	timeout     time.Duration
+	stablePolls int
+	msgs        map[string]*trackedMsg
+	wake        chan struct{}
+	ctx         context.Context
+	cancel      context.CancelFunc
+	done        chan struct{}
+}
+
+// SetInterval 设定查询的最小及最大间隔(每次查询后间隔翻倍)
+func (t *DeliveryTracker) SetInterval(min, max time.Duration) *DeliveryTracker {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.minInterval = min
+	t.maxInterval = max
+	return t
+}
+
+// SetTimeout 设定单条消息的最长跟踪时长
+func (t *DeliveryTracker) SetTimeout(timeout time.Duration) *DeliveryTracker {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.timeout = timeout
+	return t
+}
+
+// SetStablePolls 设定统计数据连续多少次查询不变时视为已稳定
+func (t *DeliveryTracker) SetStablePolls(n int) *DeliveryTracker {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	t.stablePolls = n
+	return t
+}
+
+// Track 跟踪消息的投递统计，停止跟踪后直接将空的统计结果交由 handle 处理
+func (t *DeliveryTracker) Track(msgID string) {
+	if msgID == "" {
+		return
+	}
+
+	t.lock.Lock()
+	// 在锁内检查，保证停止后 flush 取走的集合中不会再加入消息
+	if t.ctx.Err() != nil {
+		t.lock.Unlock()
+		now := time.Now()
+		t.handle(&DeliveryStats{MsgID: msgID, StartAt: now, FinishAt: now})
+		return
+	}
+	if _, ok := t.msgs[msgID]; !ok {
+		now := time.Now()
+		t.msgs[msgID] = &trackedMsg{
+			stats:    &DeliveryStats{MsgID: msgID, StartAt: now},
+			interval: t.minInterval,
+			next:     now.Add(t.minInterval),
+			deadline: now.Add(t.timeout),
+		}
+	}
+	t.lock.Unlock()
+
+	select {
+	case t.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Callback 包装推送的异步响应结果，推送成功时自动跟踪 msg_id(next 可为空)
+func (t *DeliveryTracker) Callback(next PushResultHandle) PushResultHandle {
+	return func(ctx context.Context, result *PushResult, err error) {
+		if err == nil && result != nil {
+			t.Track(result.MsgID)
+		}
+		if next != nil {
+			next(ctx, result, err)
+		}
+	}
+}
+
+// Stop 停止跟踪，尚未结束的消息按当前统计数据交由 handle 处理
+func (t *DeliveryTracker) Stop() {
+	t.cancel()
+	<-t.done
+}
+
+func (t *DeliveryTracker) run() {
+	defer close(t.done)
+
+	timer := time.NewTimer(t.nextWait())
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-t.ctx.Done():
+			t.flush()
+			return
+		case <-t.wake:
+		case <-timer.C:
+			t.poll()
+		}
+
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(t.nextWait())
+	}
+}
+
+// nextWait 距离最近一次待查询的时长
+func (t *DeliveryTracker) nextWait() time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	wait := time.Hour
+	now := time.Now()
+	for _, msg := range t.msgs {
+		if d := msg.next.Sub(now); d < wait {
+			wait = d
+		}
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait
+}
+
+func (t *DeliveryTracker) poll() {
+	t.lock.Lock()
+	now := time.Now()
+	var msgIDs []string
+	for msgID, msg := range t.msgs {
+		if !msg.next.After(now) {
+			msgIDs = append(msgIDs, msgID)
+		}
+	}
+	t.lock.Unlock()
+
+	if len(msgIDs) == 0 {
+		return
+	}
+
+	received, receivedErr := t.report.Received(t.ctx, msgIDs...)
+	messages, messagesErr := t.report.Messages(t.ctx, msgIDs...)
+	if t.ctx.Err() != nil {
+		return
+	}
+
+	err := receivedErr
+	if err == nil {
+		err = messagesErr
+	}
+
+	receivedMap := make(map[string]*ReceivedResult)
+	for _, item := range received {
+		receivedMap[item.MsgID.String()] = item
+	}
+	messagesMap := make(map[string]*MessageResult)
+	for _, item := range messages {
+		messagesMap[item.MsgID.String()] = item
+	}
+
+	var finished []*DeliveryStats
+
+	t.lock.Lock()
+	now = time.Now()
+	for _, msgID := range msgIDs {
+		msg, ok := t.msgs[msgID]
+		if !ok {
+			continue
+		}
+
+		stats := msg.stats
+		stats.Polls++
+		stats.Err = err
+
+		r, m := receivedMap[msgID], messagesMap[msgID]
+		if (r != nil || m != nil) && sameReceived(r, stats.Received) && sameMessage(m, stats.Message) {
+			msg.stable++
+		} else {
+			msg.stable = 0
+		}
+		if r != nil {
+			stats.Received = r
+		}
+		if m != nil {
+			stats.Message = m
+		}
+
+		if msg.stable >= t.stablePolls || now.After(msg.deadline) {
+			stats.Settled = msg.stable >= t.stablePolls
+			stats.FinishAt = now
+			delete(t.msgs, msgID)
+			finished = append(finished, stats)
+			continue
+		}
+
+		msg.interval *= 2
+		if msg.interval > t.maxInterval {
+			msg.interval = t.maxInterval
+		}
+		msg.next = now.Add(msg.interval)
+	}
+	t.lock.Unlock()
+
+	for _, stats := range finished {
+		t.handle(stats)
+	}
+}
+
+// flush 结束全部消息的跟踪
+func (t *DeliveryTracker) flush() {
+	t.lock.Lock()
+	msgs := t.msgs
+	t.msgs = make(map[string]*trackedMsg)
+	t.lock.Unlock()
+
+	now := time.Now()
+	for _, msg := range msgs {
+		msg.stats.FinishAt = now
+		t.handle(msg.stats)
+	}
+}
+
+// sameReceived 送达统计是否相同(忽略响应头)
+func sameReceived(a, b *ReceivedResult) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	x, y := *a, *b
+	x.HeaderItem, y.HeaderItem = nil, nil
+	return reflect.DeepEqual(x, y)
+}
+
+// sameMessage 消息统计是否相同(忽略响应头)
+func sameMessage(a, b *MessageResult) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	x, y := *a, *b
+	x.HeaderItem, y.HeaderItem = nil, nil
+	return reflect.DeepEqual(x, y)
+}
@@ -0,0 +1,147 @@
+package jpush
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+// trackerReportServer 模拟统计接口，received 按消息的查询次数返回送达数
+func trackerReportServer(received func(msgID string, polls int) int) (*httptest.Server, func(msgID string) []time.Time) {
+	var (
+		lock     sync.Mutex
+		requests int
+		polls    = make(map[string][]time.Time)
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		lock.Lock()
+		defer lock.Unlock()
+
+		// 每次响应的剩余配额不同，不影响统计数据是否稳定的判断
+		requests++
+		w.Header().Set("X-Rate-Limit-Quota", "600")
+		w.Header().Set("X-Rate-Limit-Remaining", strconv.Itoa(600-requests))
+		w.Header().Set("X-Rate-Limit-Reset", "60")
+
+		var items []string
+		for _, msgID := range strings.Split(r.URL.Query().Get("msg_ids"), ",") {
+			if r.URL.Path == "/v3/received" {
+				polls[msgID] = append(polls[msgID], time.Now())
+			}
+			n := received(msgID, len(polls[msgID]))
+			switch r.URL.Path {
+			case "/v3/received":
+				items = append(items, fmt.Sprintf(`{"msg_id":%s,"android_received":%d}`, msgID, n))
+			case "/v3/messages":
+				items = append(items, fmt.Sprintf(`{"msg_id":%s,"android":{"received":%d}}`, msgID, n))
+			}
+		}
+		w.Write([]byte("[" + strings.Join(items, ",") + "]"))
+	}))
+
+	return srv, func(msgID string) []time.Time {
+		lock.Lock()
+		defer lock.Unlock()
+		return polls[msgID]
+	}
+}
+
+func TestDeliveryTracker(t *testing.T) {
+	Convey("test delivery tracker settles with backoff", t, func() {
+		srv, polls := trackerReportServer(func(_ string, polls int) int {
+			if polls > 3 {
+				return 3
+			}
+			return polls
+		})
+		defer srv.Close()
+
+		results := make(chan *DeliveryStats, 1)
+		tracker := NewDeliveryTracker(NewReportClient(SetReportHost(srv.URL)), func(s *DeliveryStats) {
+			results <- s
+		})
+		defer tracker.Stop()
+		tracker.SetInterval(time.Millisecond*20, time.Millisecond*80).SetStablePolls(2).SetTimeout(time.Second * 10)
+		tracker.Track("1")
+
+		stats := <-results
+		So(stats.MsgID, ShouldEqual, "1")
+		So(stats.Settled, ShouldBeTrue)
+		So(stats.Err, ShouldBeNil)
+		So(stats.Received.AndroidReceived, ShouldEqual, 3)
+		So(stats.Message.Android.Received, ShouldEqual, 3)
+
+		// 第 3 次查询后数据不再变化，再查询 2 次后视为稳定
+		times := polls("1")
+		So(stats.Polls, ShouldEqual, 5)
+		So(times, ShouldHaveLength, 5)
+
+		// 查询间隔依次为 40ms、80ms、80ms、80ms(翻倍至最大间隔)
+		var gaps []time.Duration
+		for i := 1; i < len(times); i++ {
+			gaps = append(gaps, times[i].Sub(times[i-1]))
+		}
+		So(gaps[0], ShouldBeGreaterThanOrEqualTo, time.Millisecond*40)
+		So(gaps[0], ShouldBeLessThan, time.Millisecond*80)
+		for _, gap := range gaps[1:] {
+			So(gap, ShouldBeGreaterThanOrEqualTo, time.Millisecond*80)
+			So(gap, ShouldBeLessThan, time.Millisecond*160)
+		}
+	})
+
+	Convey("test delivery tracker timeout", t, func() {
+		srv, _ := trackerReportServer(func(_ string, polls int) int {
+			return polls
+		})
+		defer srv.Close()
+
+		results := make(chan *DeliveryStats, 1)
+		tracker := NewDeliveryTracker(NewReportClient(SetReportHost(srv.URL)), func(s *DeliveryStats) {
+			results <- s
+		})
+		defer tracker.Stop()
+		tracker.SetInterval(time.Millisecond*10, time.Millisecond*20).SetStablePolls(2).SetTimeout(time.Millisecond * 100)
+		tracker.Track("2")
+
+		stats := <-results
+		So(stats.MsgID, ShouldEqual, "2")
+		So(stats.Settled, ShouldBeFalse)
+		So(stats.Polls, ShouldBeGreaterThan, 1)
+		So(stats.Received.AndroidReceived, ShouldEqual, stats.Polls)
+		So(stats.FinishAt.Sub(stats.StartAt), ShouldBeGreaterThanOrEqualTo, time.Millisecond*100)
+	})
+}
+
+func TestDeliveryTrackerStop(t *testing.T) {
+	Convey("test delivery tracker stop", t, func() {
+		var stats []*DeliveryStats
+		tracker := NewDeliveryTracker(NewReportClient(), func(s *DeliveryStats) {
+			stats = append(stats, s)
+		})
+
+		callback := tracker.Callback(nil)
+		callback(context.Background(), &PushResult{MsgID: "1613113584"}, nil)
+		callback(context.Background(), nil, context.Canceled)
+		tracker.Track("1613113584")
+
+		tracker.Stop()
+		So(stats, ShouldHaveLength, 1)
+		So(stats[0].MsgID, ShouldEqual, "1613113584")
+		So(stats[0].Settled, ShouldBeFalse)
+		So(stats[0].Polls, ShouldEqual, 0)
+
+		// 停止后跟踪的消息直接交由 handle 处理
+		tracker.Track("1613113585")
+		So(stats, ShouldHaveLength, 2)
+		So(stats[1].MsgID, ShouldEqual, "1613113585")
+		So(stats[1].Settled, ShouldBeFalse)
+	})
+}
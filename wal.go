@@ -0,0 +1,200 @@
+package jpush
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// 持久化队列的日志文件名
+const walFileName = "push.wal"
+
+var errWALClosed = errors.New("push wal closed")
+
+// 日志记录的操作类型
+const (
+	walOpEnqueue = "enqueue"
+	walOpCID     = "cid"
+	walOpAck     = "ack"
+)
+
+type walRecord struct {
+	Op      string          `json:"op"`
+	ID      uint64          `json:"id"`
+	CID     string          `json:"cid,omitempty"`
+	Payload json.RawMessage `json:"payload,omitempty"`
+}
+
+// walEntry 尚未确认的推送任务
+type walEntry struct {
+	id      uint64
+	cid     string
+	payload json.RawMessage
+}
+
+// openPushWAL 打开持久化队列目录，返回尚未确认的推送任务(按入队顺序)，并压缩日志
+func openPushWAL(dir string) (*pushWAL, []*walEntry, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, nil, err
+	}
+
+	name := filepath.Join(dir, walFileName)
+	entries, seq, err := readPushWAL(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// 仅保留尚未确认的任务，写入临时文件后替换原日志
+	tmpName := name + ".tmp"
+	tmp, err := os.OpenFile(tmpName, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	encoder := json.NewEncoder(tmp)
+	for _, entry := range entries {
+		err = encoder.Encode(&walRecord{Op: walOpEnqueue, ID: entry.id, CID: entry.cid, Payload: entry.payload})
+		if err != nil {
+			tmp.Close()
+			return nil, nil, err
+		}
+	}
+
+	if err = tmp.Sync(); err != nil {
+		tmp.Close()
+		return nil, nil, err
+	} else if err = tmp.Close(); err != nil {
+		return nil, nil, err
+	} else if err = os.Rename(tmpName, name); err != nil {
+		return nil, nil, err
+	}
+
+	file, err := os.OpenFile(name, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &pushWAL{file: file, seq: seq}, entries, nil
+}
+
+// readPushWAL 读取日志中尚未确认的任务，忽略进程崩溃时写入不完整的末尾记录
+func readPushWAL(name string) ([]*walEntry, uint64, error) {
+	file, err := os.Open(name)
+	if os.IsNotExist(err) {
+		return nil, 0, nil
+	} else if err != nil {
+		return nil, 0, err
+	}
+	defer file.Close()
+
+	var seq uint64
+	pending := make(map[uint64]*walEntry)
+	decoder := json.NewDecoder(file)
+	for {
+		var record walRecord
+		if err := decoder.Decode(&record); err == io.EOF {
+			break
+		} else if err != nil {
+			if _, ok := err.(*json.SyntaxError); ok || err == io.ErrUnexpectedEOF {
+				break
+			}
+			return nil, 0, err
+		}
+
+		if record.ID > seq {
+			seq = record.ID
+		}
+
+		switch record.Op {
+		case walOpEnqueue:
+			pending[record.ID] = &walEntry{id: record.ID, cid: record.CID, payload: record.Payload}
+		case walOpCID:
+			if entry, ok := pending[record.ID]; ok {
+				entry.cid = record.CID
+			}
+		case walOpAck:
+			delete(pending, record.ID)
+		}
+	}
+
+	entries := make([]*walEntry, 0, len(pending))
+	for _, entry := range pending {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].id < entries[j].id
+	})
+	return entries, seq, nil
+}
+
+// pushWAL 推送队列的预写日志(追加写入)
+type pushWAL struct {
+	lock sync.Mutex
+	file *os.File
+	seq  uint64
+}
+
+func (w *pushWAL) append(record *walRecord) error {
+	if w.file == nil {
+		return errWALClosed
+	}
+
+	buf, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+
+	if _, err = w.file.Write(append(buf, '\n')); err != nil {
+		return err
+	}
+	return w.file.Sync()
+}
+
+// Enqueue 记录入队的推送载荷，返回任务 ID
+func (w *pushWAL) Enqueue(payload *Payload) (uint64, error) {
+	buf, err := json.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.seq++
+	err = w.append(&walRecord{Op: walOpEnqueue, ID: w.seq, CID: payload.CID, Payload: buf})
+	if err != nil {
+		return 0, err
+	}
+	return w.seq, nil
+}
+
+// SetCID 记录任务分配的推送唯一标识符，重放时沿用以避免重复推送
+func (w *pushWAL) SetCID(id uint64, cid string) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.append(&walRecord{Op: walOpCID, ID: id, CID: cid})
+}
+
+// Ack 确认任务已完成(推送成功或最终失败)
+func (w *pushWAL) Ack(id uint64) error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	return w.append(&walRecord{Op: walOpAck, ID: id})
+}
+
+// Close 关闭日志文件(重复调用无影响)
+func (w *pushWAL) Close() error {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	if w.file == nil {
+		return nil
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}
@@ -0,0 +1,118 @@
+package jpush
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestPushWAL(t *testing.T) {
+	Convey("test push wal", t, func() {
+		dir, err := os.MkdirTemp("", "jpush")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		wal, entries, err := openPushWAL(dir)
+		So(err, ShouldBeNil)
+		So(entries, ShouldBeEmpty)
+
+		payload := &Payload{
+			Platform:     NewPlatform().All(),
+			Audience:     NewAudience().All(),
+			Notification: NewNotification().SetAlert("推送通知测试"),
+		}
+		id1, err := wal.Enqueue(payload)
+		So(err, ShouldBeNil)
+		id2, err := wal.Enqueue(payload)
+		So(err, ShouldBeNil)
+		So(wal.SetCID(id2, "cid-2"), ShouldBeNil)
+		So(wal.Ack(id1), ShouldBeNil)
+		So(wal.Close(), ShouldBeNil)
+
+		// 模拟进程崩溃时写入不完整的记录
+		file, err := os.OpenFile(filepath.Join(dir, walFileName), os.O_APPEND|os.O_WRONLY, 0644)
+		So(err, ShouldBeNil)
+		file.WriteString(`{"op":"ack","id":`)
+		file.Close()
+
+		wal, entries, err = openPushWAL(dir)
+		So(err, ShouldBeNil)
+		So(entries, ShouldHaveLength, 1)
+		So(entries[0].id, ShouldEqual, id2)
+		So(entries[0].cid, ShouldEqual, "cid-2")
+
+		replayed := new(Payload)
+		So(json.Unmarshal(entries[0].payload, replayed), ShouldBeNil)
+		So(replayed.Platform.IsAll, ShouldBeTrue)
+		So(replayed.Notification.Alert, ShouldEqual, "推送通知测试")
+
+		id3, err := wal.Enqueue(payload)
+		So(err, ShouldBeNil)
+		So(id3, ShouldBeGreaterThan, id2)
+		So(wal.Close(), ShouldBeNil)
+	})
+}
+
+func TestClientReplay(t *testing.T) {
+	Convey("test client replays the push queue", t, func() {
+		dir, err := os.MkdirTemp("", "jpush")
+		So(err, ShouldBeNil)
+		defer os.RemoveAll(dir)
+
+		wal, _, err := openPushWAL(dir)
+		So(err, ShouldBeNil)
+		id, err := wal.Enqueue(&Payload{
+			Platform:     NewPlatform().All(),
+			Audience:     NewAudience().All(),
+			Notification: NewNotification().SetAlert("推送通知测试"),
+		})
+		So(err, ShouldBeNil)
+		So(wal.SetCID(id, "cid-1"), ShouldBeNil)
+		So(wal.Close(), ShouldBeNil)
+
+		cids := make(chan string, 1)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var payload Payload
+			json.NewDecoder(r.Body).Decode(&payload)
+			cids <- payload.CID
+			w.Write([]byte(`{"sendno":"0","msg_id":"m-1"}`))
+		}))
+		defer srv.Close()
+
+		results := make(chan *PushResult, 1)
+		cli, err := NewClientWithQueue(1,
+			SetHost(srv.URL),
+			SetAppKey(appKey),
+			SetMasterSecret(masterSecret),
+			SetQueueDir(dir),
+			SetReplayHandle(func(_ context.Context, result *PushResult, err error) {
+				results <- result
+			}),
+		)
+		So(err, ShouldBeNil)
+		So(<-cids, ShouldEqual, "cid-1")
+		So((<-results).MsgID, ShouldEqual, "m-1")
+		So(cli.Shutdown(context.Background()), ShouldBeNil)
+
+		_, entries, err := openPushWAL(dir)
+		So(err, ShouldBeNil)
+		So(entries, ShouldBeEmpty)
+
+		// 持久化队列目录不可用
+		file := filepath.Join(dir, "file")
+		So(os.WriteFile(file, nil, 0644), ShouldBeNil)
+		_, err = NewClientWithQueue(1, SetQueueDir(file))
+		So(err, ShouldNotBeNil)
+
+		broken := NewClient(1, SetQueueDir(file))
+		So(broken.Err(), ShouldNotBeNil)
+		So(broken.Push(context.Background(), &Payload{}, nil), ShouldEqual, broken.Err())
+		broken.Terminate()
+	})
+}
@@ -0,0 +1,104 @@
+package jpush
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// 消息的可撤回时间
+const withdrawWindow = time.Hour * 24
+
+var (
+	// ErrWithdrawExpired 消息已超出可撤回时间
+	ErrWithdrawExpired = errors.New("withdraw expired")
+	// ErrWithdrawRepeated 消息已通过该客户端撤回
+	ErrWithdrawRepeated = errors.New("withdraw repeated")
+)
+
+func withdraw(ctx context.Context, opts *options, msgID string) error {
+	if msgID == "" {
+		return ErrInvalidMsgID
+	}
+
+	router := fmt.Sprintf("/v3/push/%s", url.PathEscape(msgID))
+	resp, err := pushRequest(ctx, opts, router, http.MethodDelete, nil)
+	if err != nil {
+		return newWithdrawError(msgID, err)
+	}
+	resp.Close()
+	return nil
+}
+
+// newWithdrawError 将接口错误包装为 *WithdrawError，其他错误原样返回
+func newWithdrawError(msgID string, err error) error {
+	var e *Error
+	if !errors.As(err, &e) {
+		return err
+	}
+	return &WithdrawError{MsgID: msgID, Err: e}
+}
+
+// withdrawnSet 已撤回的消息(超出可撤回时间后移除)
+type withdrawnSet struct {
+	lock sync.Mutex
+	msgs map[string]time.Time
+}
+
+func (s *withdrawnSet) has(msgID string) bool {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	_, ok := s.msgs[msgID]
+	return ok
+}
+
+func (s *withdrawnSet) add(msgID string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	now := time.Now()
+	if s.msgs == nil {
+		s.msgs = make(map[string]time.Time)
+	}
+	for id, t := range s.msgs {
+		if now.Sub(t) > withdrawWindow {
+			delete(s.msgs, id)
+		}
+	}
+	s.msgs[msgID] = now
+}
+
+// WithdrawError 撤回错误，可通过 errors.Is 判断是否为 ErrWithdrawExpired 或 ErrWithdrawRepeated，
+// 接口返回的错误可通过 errors.As 获取 *Error
+type WithdrawError struct {
+	MsgID  string
+	Err    *Error
+	reason error
+}
+
+func (e *WithdrawError) Error() string {
+	if e.Err == nil {
+		return fmt.Sprintf("withdraw %s: %v", e.MsgID, e.reason)
+	} else if e.reason == nil {
+		return fmt.Sprintf("withdraw %s: %s", e.MsgID, e.Err.Error())
+	}
+	return fmt.Sprintf("withdraw %s: %v: %s", e.MsgID, e.reason, e.Err.Error())
+}
+
+// Is 实现 errors.Is 接口
+func (e *WithdrawError) Is(target error) bool {
+	return e.reason != nil && target == e.reason
+}
+
+// Unwrap 返回接口错误
+func (e *WithdrawError) Unwrap() error {
+	if e.Err == nil {
+		return nil
+	}
+	return e.Err
+}
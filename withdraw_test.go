@@ -0,0 +1,61 @@
+package jpush
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	. "github.com/smartystreets/goconvey/convey"
+)
+
+func TestWithdraw(t *testing.T) {
+	Convey("test withdraw", t, func() {
+		var paths []string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			paths = append(paths, r.Method+" "+r.URL.Path)
+			if r.URL.Path == "/v3/push/m-2" {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"error":{"code":1003,"message":"msg_id invalid"}}`))
+			}
+		}))
+		defer srv.Close()
+
+		cli := NewClient(1, SetHost(srv.URL), SetAppKey(appKey), SetMasterSecret(masterSecret))
+		defer cli.Terminate()
+		ctx := context.Background()
+
+		So(cli.Withdraw(ctx, "m-1"), ShouldBeNil)
+		So(paths, ShouldResemble, []string{"DELETE /v3/push/m-1"})
+
+		// 已撤回的消息不再请求接口
+		err := cli.WithdrawResult(ctx, &PushResult{MsgID: "m-1", PushedAt: time.Now()})
+		So(errors.Is(err, ErrWithdrawRepeated), ShouldBeTrue)
+		So(paths, ShouldHaveLength, 1)
+
+		err = cli.Withdraw(ctx, "m-2")
+		var we *WithdrawError
+		So(errors.As(err, &we), ShouldBeTrue)
+		So(we.MsgID, ShouldEqual, "m-2")
+		So(errors.Is(err, ErrWithdrawExpired), ShouldBeFalse)
+		So(errors.Is(err, ErrWithdrawRepeated), ShouldBeFalse)
+
+		var e *Error
+		So(errors.As(err, &e), ShouldBeTrue)
+		So(e.StatusCode, ShouldEqual, http.StatusBadRequest)
+		So(e.ErrorItem.Code, ShouldEqual, 1003)
+		So(paths[len(paths)-1], ShouldEqual, "DELETE /v3/push/m-2")
+
+		err = cli.WithdrawResult(ctx, &PushResult{MsgID: "m-3", PushedAt: time.Now().Add(-withdrawWindow * 2)})
+		So(errors.Is(err, ErrWithdrawExpired), ShouldBeTrue)
+
+		n := len(paths)
+		So(cli.WithdrawResult(ctx, nil), ShouldEqual, ErrInvalidMsgID)
+		So(cli.Withdraw(ctx, ""), ShouldEqual, ErrInvalidMsgID)
+		So(paths, ShouldHaveLength, n)
+
+		So(newWithdrawError("m-4", context.Canceled), ShouldEqual, context.Canceled)
+	})
+}